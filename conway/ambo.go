@@ -1,6 +1,15 @@
 package conway
 
-type AmboOp struct{}
+import (
+	"cmp"
+	"slices"
+)
+
+// AmboOp rectifies a polyhedron, placing new vertices at edge midpoints.
+// Workers sets how many goroutines build the output; zero uses one per available CPU.
+type AmboOp struct {
+	Workers int
+}
 
 func (a AmboOp) Symbol() string {
 	return "a"
@@ -11,48 +20,88 @@ func (a AmboOp) Name() string {
 }
 
 func (a AmboOp) Apply(p *Polyhedron) *Polyhedron {
-	ambo := NewPolyhedron("a" + p.Name)
+	vertices := sortedVertices(p)
+	edges := sortedEdges(p)
+	faces := sortedFaces(p)
+	edgeIndex := indexEdges(edges)
+
+	// Face slots hold the original faces first, then one slot per original vertex.
+	b := newMeshBuilder(len(edges), len(faces)+len(vertices))
+	b.trace(a.Symbol(), p, func(i, j int) Source {
+		return amboEdgeSource(edges[i], edges[j])
+	})
+
+	parallelFor(a.Workers, len(edges), func(start, end int) {
+		for i := start; i < end; i++ {
+			b.positions[i] = edges[i].Midpoint()
+			b.vertexSources[i] = Source{Kind: EdgeElement, ID: edges[i].ID}
+		}
+	})
 
-	edgeVertices := make(map[int]*Vertex)
+	parallelFor(a.Workers, len(faces), func(start, end int) {
+		for i := start; i < end; i++ {
+			faceVertices := make([]int, len(faces[i].Edges))
 
-	for _, edge := range p.Edges {
-		midpoint := edge.Midpoint()
+			for j, edge := range faces[i].Edges {
+				faceVertices[j] = edgeIndex[edge.ID]
+			}
 
-		v := ambo.AddVertex(midpoint)
+			b.faces[i] = faceVertices
+			b.faceSources[i] = Source{Kind: FaceElement, ID: faces[i].ID}
+		}
+	})
 
-		edgeVertices[edge.ID] = v
-	}
+	parallelFor(a.Workers, len(vertices), func(start, end int) {
+		for i := start; i < end; i++ {
+			if len(vertices[i].Edges) < 3 {
+				continue
+			}
 
-	for _, face := range p.Faces {
-		faceVertices := make([]*Vertex, len(face.Edges))
+			orderedEdges := OrderEdgesAroundVertex(vertices[i])
 
-		for i, edge := range face.Edges {
-			faceVertices[i] = edgeVertices[edge.ID]
+			vertexFaceVertices := make([]int, len(orderedEdges))
+
+			for j, edge := range orderedEdges {
+				vertexFaceVertices[j] = edgeIndex[edge.ID]
+			}
+
+			b.faces[len(faces)+i] = vertexFaceVertices
+			b.faceSources[len(faces)+i] = Source{Kind: VertexElement, ID: vertices[i].ID}
 		}
+	})
 
-		ambo.AddFace(faceVertices)
-	}
+	ambo := b.build("a"+p.Name, a.Workers)
 
-	for _, vertex := range p.Vertices {
-		if len(vertex.Edges) >= 3 {
-			orderedEdges := OrderEdgesAroundVertex(vertex)
+	ambo.Normalize()
 
-			vertexFaceVertices := make([]*Vertex, len(orderedEdges))
+	return ambo
+}
 
-			for i, edge := range orderedEdges {
-				vertexFaceVertices[i] = edgeVertices[edge.ID]
-			}
+// amboEdgeSource returns the source of the edge joining the midpoints of two
+// original edges: the face both edges bound, choosing the lowest ID if there are
+// several, or their shared vertex if they have no face in common.
+func amboEdgeSource(e1, e2 *Edge) Source {
+	var face *Face
 
-			ambo.AddFace(vertexFaceVertices)
+	for _, f := range e1.Faces {
+		if _, ok := e2.Faces[f.ID]; ok && (face == nil || f.ID < face.ID) {
+			face = f
 		}
 	}
 
-	ambo.Normalize()
+	if face != nil {
+		return Source{Kind: FaceElement, ID: face.ID}
+	}
 
-	return ambo
+	shared := e1.V1
+	if !edgeConnectsToVertex(e2, shared.ID) {
+		shared = e1.V2
+	}
+
+	return Source{Kind: VertexElement, ID: shared.ID}
 }
 
-// convertEdgesToSlice converts vertex edges map to slice.
+// convertEdgesToSlice converts vertex edges map to a slice ordered by edge ID.
 func convertEdgesToSlice(v *Vertex) []*Edge {
 	edges := make([]*Edge, 0, len(v.Edges))
 
@@ -60,6 +109,8 @@ func convertEdgesToSlice(v *Vertex) []*Edge {
 		edges = append(edges, e)
 	}
 
+	slices.SortFunc(edges, func(a, b *Edge) int { return cmp.Compare(a.ID, b.ID) })
+
 	return edges
 }
 
@@ -95,18 +146,22 @@ func findNextEdgeInFace(face *Face, currentEdgeID, vertexID int, visited map[int
 }
 
 // findNextEdgeInFaces searches through faces to find the next edge to add.
+// When several edges qualify the one with the lowest ID is chosen, keeping the order deterministic.
 func findNextEdgeInFaces(v *Vertex, currentEdge *Edge, visited map[int]bool) *Edge {
+	var next *Edge
+
 	for _, face := range v.Faces {
 		if !faceContainsEdge(face, currentEdge.ID) {
 			continue
 		}
 
-		if nextEdge := findNextEdgeInFace(face, currentEdge.ID, v.ID, visited); nextEdge != nil {
-			return nextEdge
+		nextEdge := findNextEdgeInFace(face, currentEdge.ID, v.ID, visited)
+		if nextEdge != nil && (next == nil || nextEdge.ID < next.ID) {
+			next = nextEdge
 		}
 	}
 
-	return nil
+	return next
 }
 
 // findNextUnvisitedEdge finds any unvisited edge (fallback).
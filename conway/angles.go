@@ -0,0 +1,272 @@
+package conway
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+const (
+	// angleHistogramBins is the number of equal-width bins in an angle histogram.
+	angleHistogramBins = 10
+	// descartesTolerance is the largest difference between the total angular defect
+	// and 2π times the Euler characteristic for Descartes' theorem to hold.
+	descartesTolerance = 1e-9
+)
+
+// DihedralAngle returns the interior angle in radians between the two faces of the
+// edge: less than π where the surface is convex, π where it is flat and more than π
+// at a reflex edge. It reports false for edges without exactly two faces, or whose
+// faces traverse it in the same direction.
+func (e *Edge) DihedralAngle() (float64, bool) {
+	if len(e.Faces) != 2 {
+		return 0, false
+	}
+
+	var forward, backward *Face
+
+	for _, f := range e.Faces {
+		i := FindEdgeIndex(f, e)
+
+		switch {
+		case i < 0:
+			return 0, false
+		case f.Vertices[i].ID == e.V1.ID:
+			forward = f
+		default:
+			backward = f
+		}
+	}
+
+	if forward == nil || backward == nil {
+		return 0, false
+	}
+
+	// Turning from one outward normal to the other about the edge direction, as the
+	// forward face traverses it, is positive at convex edges.
+	n1, n2 := forward.Normal(), backward.Normal()
+	axis := e.V2.Position.Sub(e.V1.Position).Normalize()
+	turn := math.Atan2(n1.Cross(n2).Dot(axis), n1.Dot(n2))
+
+	return math.Pi - turn, true
+}
+
+// Angles returns the interior angle in radians at each corner of the face, in the
+// order of its vertices. Reflex corners, turning clockwise about the face normal,
+// have angles greater than π.
+func (f *Face) Angles() []float64 {
+	n := len(f.Vertices)
+	normal := f.Normal()
+	angles := make([]float64, n)
+
+	for i, v := range f.Vertices {
+		next := f.Vertices[(i+1)%n].Position.Sub(v.Position)
+		prev := f.Vertices[(i+n-1)%n].Position.Sub(v.Position)
+
+		angle := math.Atan2(next.Cross(prev).Dot(normal), next.Dot(prev))
+		if angle < 0 {
+			angle += 2 * math.Pi
+		}
+
+		angles[i] = angle
+	}
+
+	return angles
+}
+
+// AngularDefect returns 2π minus the sum of the face angles at the vertex: positive
+// where the surface is convex, zero where it is flat.
+func (v *Vertex) AngularDefect() float64 {
+	defect := 2 * math.Pi
+
+	for _, f := range v.Faces {
+		for i, corner := range f.Vertices {
+			if corner.ID == v.ID {
+				defect -= f.Angles()[i]
+			}
+		}
+	}
+
+	return defect
+}
+
+// AngleHistogram summarises the angles of one type of edge, corner or vertex.
+type AngleHistogram struct {
+	Type  string  // Type of element, as described on the AngleStats field
+	Count int     // Number of angles
+	Min   float64 // Smallest angle in radians
+	Max   float64 // Largest angle in radians
+	Mean  float64 // Mean angle in radians
+	Bins  []int   // Counts in equal-width bins from Min to Max
+}
+
+// AngleStats holds the angles of a polyhedron for quality analysis.
+type AngleStats struct {
+	DihedralAngles map[int]float64   // Interior dihedral angle by edge ID, for edges with two faces
+	FaceAngles     map[int][]float64 // Corner angles by face ID, in the order of the face's vertices
+	Defects        map[int]float64   // Angular defect by vertex ID
+
+	// TotalDefect is the sum of the angular defects, which by Descartes' theorem is
+	// 2π times the Euler characteristic: 4π for a polyhedron with no holes.
+	TotalDefect    float64
+	ExpectedDefect float64 // 2π times the Euler characteristic
+	DescartesHolds bool    // TotalDefect matches ExpectedDefect within tolerance
+
+	// DihedralTypes groups dihedral angles by the degrees of the faces either side,
+	// smallest first, such as "3.4" for edges between a triangle and a square.
+	DihedralTypes []AngleHistogram
+	// FaceAngleTypes groups corner angles by face degree, such as "6" for hexagons.
+	FaceAngleTypes []AngleHistogram
+	// DefectTypes groups angular defects by vertex degree.
+	DefectTypes []AngleHistogram
+}
+
+// CalculateAngleStats computes dihedral angles, face angles and angular defects with
+// a check of Descartes' theorem, and histograms of each grouped by element type.
+// Faces should be wound outwards, as checked by ValidateWinding.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) CalculateAngleStats() *AngleStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stats := &AngleStats{
+		DihedralAngles: make(map[int]float64, len(p.Edges)),
+		FaceAngles:     make(map[int][]float64, len(p.Faces)),
+		Defects:        make(map[int]float64, len(p.Vertices)),
+		TotalDefect:    0,
+		ExpectedDefect: 2 * math.Pi * float64(len(p.Vertices)-len(p.Edges)+len(p.Faces)),
+		DescartesHolds: false,
+		DihedralTypes:  nil,
+		FaceAngleTypes: nil,
+		DefectTypes:    nil,
+	}
+
+	dihedrals := newAngleGroups()
+
+	for _, e := range sortedEdges(p) {
+		angle, ok := e.DihedralAngle()
+		if !ok {
+			continue
+		}
+
+		degrees := make([]int, 0, len(e.Faces))
+
+		for _, f := range e.Faces {
+			degrees = append(degrees, len(f.Vertices))
+		}
+
+		slices.Sort(degrees)
+
+		stats.DihedralAngles[e.ID] = angle
+		dihedrals.add(fmt.Sprintf("%d.%d", degrees[0], degrees[1]), angle)
+	}
+
+	corners := newAngleGroups()
+	defects := newAngleGroups()
+
+	for _, v := range sortedVertices(p) {
+		stats.Defects[v.ID] = 2 * math.Pi
+	}
+
+	for _, f := range sortedFaces(p) {
+		angles := f.Angles()
+		stats.FaceAngles[f.ID] = angles
+
+		for i, angle := range angles {
+			stats.Defects[f.Vertices[i].ID] -= angle
+			corners.add(strconv.Itoa(len(f.Vertices)), angle)
+		}
+	}
+
+	for _, v := range sortedVertices(p) {
+		stats.TotalDefect += stats.Defects[v.ID]
+		defects.add(strconv.Itoa(v.Degree()), stats.Defects[v.ID])
+	}
+
+	stats.DescartesHolds = math.Abs(stats.TotalDefect-stats.ExpectedDefect) <= descartesTolerance
+	stats.DihedralTypes = dihedrals.histograms()
+	stats.FaceAngleTypes = corners.histograms()
+	stats.DefectTypes = defects.histograms()
+
+	return stats
+}
+
+// angleGroups collects angles by type label in order of first appearance.
+type angleGroups struct {
+	order  []string
+	angles map[string][]float64
+}
+
+func newAngleGroups() *angleGroups {
+	return &angleGroups{order: nil, angles: make(map[string][]float64)}
+}
+
+func (g *angleGroups) add(label string, angle float64) {
+	if _, ok := g.angles[label]; !ok {
+		g.order = append(g.order, label)
+	}
+
+	g.angles[label] = append(g.angles[label], angle)
+}
+
+// histograms returns a histogram per type, sorted by type.
+func (g *angleGroups) histograms() []AngleHistogram {
+	labels := slices.Clone(g.order)
+	slices.SortFunc(labels, compareTypeLabels)
+
+	histograms := make([]AngleHistogram, 0, len(labels))
+
+	for _, label := range labels {
+		histograms = append(histograms, newAngleHistogram(label, g.angles[label]))
+	}
+
+	return histograms
+}
+
+// compareTypeLabels orders labels such as "3.10" and "4.4" by their numbers.
+func compareTypeLabels(a, b string) int {
+	var a1, a2, b1, b2 int
+
+	_, _ = fmt.Sscanf(a, "%d.%d", &a1, &a2) // Labels of a single number leave the second zero
+	_, _ = fmt.Sscanf(b, "%d.%d", &b1, &b2)
+
+	return cmp.Or(cmp.Compare(a1, b1), cmp.Compare(a2, b2))
+}
+
+// newAngleHistogram summarises a non-empty set of angles.
+func newAngleHistogram(label string, angles []float64) AngleHistogram {
+	h := AngleHistogram{
+		Type:  label,
+		Count: len(angles),
+		Min:   math.Inf(1),
+		Max:   math.Inf(-1),
+		Mean:  0,
+		Bins:  make([]int, angleHistogramBins),
+	}
+
+	sum := 0.0
+
+	for _, a := range angles {
+		h.Min = math.Min(h.Min, a)
+		h.Max = math.Max(h.Max, a)
+		sum += a
+	}
+
+	// Clamp the mean so rounding cannot put it outside the range of equal angles.
+	h.Mean = math.Min(math.Max(sum/float64(len(angles)), h.Min), h.Max)
+
+	width := (h.Max - h.Min) / angleHistogramBins
+
+	for _, a := range angles {
+		bin := 0
+		if width > 0 {
+			bin = min(int((a-h.Min)/width), angleHistogramBins-1)
+		}
+
+		h.Bins[bin]++
+	}
+
+	return h
+}
@@ -0,0 +1,143 @@
+package conway_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCubeAngles(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+
+	for _, e := range p.Edges {
+		angle, ok := e.DihedralAngle()
+		require.True(t, ok)
+		assert.InDelta(t, math.Pi/2, angle, 1e-12)
+	}
+
+	for _, f := range p.Faces {
+		for _, angle := range f.Angles() {
+			assert.InDelta(t, math.Pi/2, angle, 1e-12)
+		}
+	}
+
+	for _, v := range p.Vertices {
+		assert.InDelta(t, math.Pi/2, v.AngularDefect(), 1e-12)
+	}
+}
+
+func TestCalculateAngleStats(t *testing.T) {
+	t.Parallel()
+
+	// The truncated icosahedron has hexagon-hexagon and hexagon-pentagon edges, and
+	// every vertex has the same defect.
+	p := conway.MustParse("tI")
+	stats := p.CalculateAngleStats()
+
+	assert.Len(t, stats.DihedralAngles, len(p.Edges))
+	assert.Len(t, stats.FaceAngles, len(p.Faces))
+	assert.Len(t, stats.Defects, len(p.Vertices))
+
+	assert.InDelta(t, 4*math.Pi, stats.TotalDefect, 1e-9)
+	assert.InDelta(t, 4*math.Pi, stats.ExpectedDefect, 1e-12)
+	assert.True(t, stats.DescartesHolds)
+
+	require.Len(t, stats.DihedralTypes, 2)
+	assert.Equal(t, "5.6", stats.DihedralTypes[0].Type)
+	assert.Equal(t, 60, stats.DihedralTypes[0].Count)
+	assert.Equal(t, "6.6", stats.DihedralTypes[1].Type)
+	assert.Equal(t, 30, stats.DihedralTypes[1].Count)
+
+	for _, h := range stats.DihedralTypes {
+		assert.Less(t, h.Max, math.Pi, "every edge of a convex polyhedron is convex")
+		assert.LessOrEqual(t, h.Min, h.Mean)
+		assert.LessOrEqual(t, h.Mean, h.Max)
+
+		total := 0
+
+		for _, n := range h.Bins {
+			total += n
+		}
+
+		assert.Equal(t, h.Count, total)
+	}
+
+	require.Len(t, stats.FaceAngleTypes, 2)
+	assert.Equal(t, "5", stats.FaceAngleTypes[0].Type)
+	assert.Equal(t, 12*5, stats.FaceAngleTypes[0].Count)
+	assert.InDelta(t, 3*math.Pi/5, stats.FaceAngleTypes[0].Mean, 1e-9)
+
+	require.Len(t, stats.DefectTypes, 1)
+	assert.Equal(t, "3", stats.DefectTypes[0].Type)
+	assert.InDelta(t, 4*math.Pi/60, stats.DefectTypes[0].Mean, 1e-9)
+}
+
+func TestReflexAngles(t *testing.T) {
+	t.Parallel()
+
+	// A prism on an L-shaped hexagon has one reflex corner on each cap and a reflex
+	// edge joining them.
+	p := conway.NewPolyhedron("L prism")
+	outline := [][2]float64{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}
+
+	var bottom, top []*conway.Vertex
+
+	for _, xy := range outline {
+		bottom = append(bottom, p.AddVertex(conway.Vector3{X: xy[0], Y: xy[1], Z: 0}))
+		top = append(top, p.AddVertex(conway.Vector3{X: xy[0], Y: xy[1], Z: 1}))
+	}
+
+	base := slices.Clone(bottom)
+	slices.Reverse(base)
+	p.AddFace(base)
+
+	lid := p.AddFace(top)
+
+	for i := range outline {
+		j := (i + 1) % len(outline)
+		p.AddFace([]*conway.Vertex{bottom[i], bottom[j], top[j], top[i]})
+	}
+
+	require.NoError(t, p.ValidateWinding())
+
+	angles := lid.Angles()
+	require.Len(t, angles, len(outline))
+	assert.InDelta(t, math.Pi/2, angles[0], 1e-12)
+	assert.InDelta(t, 3*math.Pi/2, angles[3], 1e-12)
+	assert.InDelta(t, 4*math.Pi, sumAngles(angles), 1e-12, "a hexagon's angles sum to 4π")
+
+	stats := p.CalculateAngleStats()
+	reflex := 0
+
+	for id, angle := range stats.DihedralAngles {
+		e := p.Edges[id]
+
+		if e.V1.Position.X == 1 && e.V1.Position.Y == 1 && e.V2.Position.X == 1 && e.V2.Position.Y == 1 {
+			assert.InDelta(t, 3*math.Pi/2, angle, 1e-12)
+		}
+
+		if angle > math.Pi {
+			reflex++
+		}
+	}
+
+	assert.Equal(t, 1, reflex)
+	assert.True(t, stats.DescartesHolds)
+	assert.InDelta(t, -math.Pi/2, stats.Defects[top[3].ID], 1e-12, "saddle vertices have negative defect")
+}
+
+func sumAngles(values []float64) float64 {
+	total := 0.0
+
+	for _, v := range values {
+		total += v
+	}
+
+	return total
+}
@@ -0,0 +1,432 @@
+package conway
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// PropagationRule decides how an attribute reaches the elements an operation creates.
+// Every output element has a source element in the input (see Provenance); the rule
+// determines what the output element receives from it.
+type PropagationRule int
+
+const (
+	// Inherit copies the value of the source element, if it has one. A face kised
+	// from a red face stays red, while a face cut from a vertex takes the vertex's value.
+	Inherit PropagationRule = iota
+	// Blend combines the values of the input elements of the output's kind around the
+	// source: the faces around a vertex for a face created from that vertex, the
+	// endpoints of an edge for a vertex created from that edge, and so on. When the
+	// source has the output's kind, or none of its neighbours have a value, the
+	// source's own value is used.
+	Blend
+	// Reset leaves the attribute unset on every output element.
+	Reset
+)
+
+// String returns the lower-case name of the rule.
+func (r PropagationRule) String() string {
+	switch r {
+	case Inherit:
+		return "inherit"
+	case Blend:
+		return "blend"
+	case Reset:
+		return "reset"
+	default:
+		return fmt.Sprintf("PropagationRule(%d)", int(r))
+	}
+}
+
+// attributeKey is the untyped definition shared by every value stored under a key.
+type attributeKey struct {
+	name  string
+	rule  PropagationRule            // Rule for operations without an override
+	rules map[string]PropagationRule // Overrides by operation symbol
+	blend func(values []any) any     // Combines values under Blend; nil keeps the first
+}
+
+// ruleFor returns the propagation rule for an operation symbol.
+func (k *attributeKey) ruleFor(operation string) PropagationRule {
+	if rule, ok := k.rules[operation]; ok {
+		return rule
+	}
+
+	return k.rule
+}
+
+// attributeValue is a value stored on an element together with its key's definition,
+// so operations can propagate it without a registry of keys.
+type attributeValue struct {
+	value any
+	key   *attributeKey
+}
+
+// attributeSet holds an element's attributes by key name.
+type attributeSet map[string]attributeValue
+
+// Element is a vertex, edge or face that can carry attributes.
+type Element interface {
+	attributes() *attributeSet
+}
+
+func (v *Vertex) attributes() *attributeSet { return &v.attrs }
+
+func (e *Edge) attributes() *attributeSet { return &e.attrs }
+
+func (f *Face) attributes() *attributeSet { return &f.attrs }
+
+// AttributeKey is a typed handle for an attribute stored on vertices, edges or faces.
+// Keys are identified by name, so two keys with the same name and type read and write
+// the same attribute; the definition used by the most recent Set decides propagation.
+//
+// Keys are immutable values: WithRule and WithBlend return modified copies.
+// Attribute values are shared, not copied, by Clone and by operations, so values
+// such as slices should be treated as immutable once set.
+//
+// Attributes are kept by Clone but are not part of the JSON or binary encodings.
+// They are not synchronized: set them before sharing a polyhedron between
+// goroutines, or guard writes externally.
+type AttributeKey[T any] struct {
+	def *attributeKey
+}
+
+// NewAttributeKey creates a key whose values propagate through operations by rule.
+func NewAttributeKey[T any](name string, rule PropagationRule) AttributeKey[T] {
+	return AttributeKey[T]{def: &attributeKey{name: name, rule: rule, rules: nil, blend: nil}}
+}
+
+// Name returns the key's name.
+func (k AttributeKey[T]) Name() string {
+	return k.def.name
+}
+
+// Rule returns the propagation rule used for the operation with the given symbol.
+func (k AttributeKey[T]) Rule(operation string) PropagationRule {
+	return k.def.ruleFor(operation)
+}
+
+// WithRule returns a copy of the key that uses rule for the operation with the given
+// symbol, such as "k" or "t". Compound operations such as "j" are looked up by their
+// own symbol.
+func (k AttributeKey[T]) WithRule(operation string, rule PropagationRule) AttributeKey[T] {
+	def := *k.def
+	def.rules = maps.Clone(k.def.rules)
+
+	if def.rules == nil {
+		def.rules = make(map[string]PropagationRule)
+	}
+
+	def.rules[operation] = rule
+
+	return AttributeKey[T]{def: &def}
+}
+
+// WithBlend returns a copy of the key that combines values with blend under the
+// Blend rule. Without a blend function the first value, in ID order, is used.
+func (k AttributeKey[T]) WithBlend(blend func(values []T) T) AttributeKey[T] {
+	def := *k.def
+	def.blend = func(values []any) any {
+		typed := make([]T, 0, len(values))
+
+		for _, v := range values {
+			if tv, ok := v.(T); ok {
+				typed = append(typed, tv)
+			}
+		}
+
+		return blend(typed)
+	}
+
+	return AttributeKey[T]{def: &def}
+}
+
+// Get returns the value stored on the element, and false if it has none.
+func (k AttributeKey[T]) Get(e Element) (T, bool) {
+	stored, ok := (*e.attributes())[k.def.name]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	value, ok := stored.value.(T)
+
+	return value, ok
+}
+
+// Set stores a value on the element.
+func (k AttributeKey[T]) Set(e Element, value T) {
+	attrs := e.attributes()
+
+	if *attrs == nil {
+		*attrs = make(attributeSet)
+	}
+
+	(*attrs)[k.def.name] = attributeValue{value: value, key: k.def}
+}
+
+// Delete removes the value from the element.
+func (k AttributeKey[T]) Delete(e Element) {
+	delete(*e.attributes(), k.def.name)
+}
+
+// ColorAttribute returns the standard key for element colours. Colours are inherited
+// from the source element and averaged when blended.
+func ColorAttribute() AttributeKey[Color] {
+	return NewAttributeKey[Color]("color", Inherit).WithBlend(BlendColors)
+}
+
+// TagsAttribute returns the standard key for element labels. Tags are inherited from
+// the source element, and blending takes the sorted union of the tags.
+func TagsAttribute() AttributeKey[[]string] {
+	return NewAttributeKey[[]string]("tags", Inherit).WithBlend(func(values [][]string) []string {
+		return slices.Compact(slices.Sorted(slices.Values(slices.Concat(values...))))
+	})
+}
+
+// UV is a texture coordinate.
+type UV struct {
+	U, V float64
+}
+
+// UVAttribute returns the standard key for vertex texture coordinates. Coordinates
+// are blended, so a vertex created on an edge gets the average of its endpoints.
+func UVAttribute() AttributeKey[UV] {
+	return NewAttributeKey[UV]("uv", Blend).WithBlend(func(values []UV) UV {
+		var sum UV
+
+		for _, uv := range values {
+			sum.U += uv.U
+			sum.V += uv.V
+		}
+
+		n := float64(max(len(values), 1))
+
+		return UV{U: sum.U / n, V: sum.V / n}
+	})
+}
+
+// hasAttributes reports whether any element of the polyhedron carries an attribute.
+func (p *Polyhedron) hasAttributes() bool {
+	for _, v := range p.Vertices {
+		if len(v.attrs) > 0 {
+			return true
+		}
+	}
+
+	for _, e := range p.Edges {
+		if len(e.attrs) > 0 {
+			return true
+		}
+	}
+
+	for _, f := range p.Faces {
+		if len(f.attrs) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// element returns the element a source refers to, or nil if it does not exist.
+func (p *Polyhedron) element(src Source) Element {
+	switch src.Kind {
+	case VertexElement:
+		if v, ok := p.Vertices[src.ID]; ok {
+			return v
+		}
+	case EdgeElement:
+		if e, ok := p.Edges[src.ID]; ok {
+			return e
+		}
+	case FaceElement:
+		if f, ok := p.Faces[src.ID]; ok {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// sortedByID returns the values of an element map ordered by ID.
+func sortedByID[E Element](elements map[int]E) []Element {
+	ids := slices.Sorted(maps.Keys(elements))
+	sorted := make([]Element, len(ids))
+
+	for i, id := range ids {
+		sorted[i] = elements[id]
+	}
+
+	return sorted
+}
+
+// neighbours returns the elements of the given kind around el, in a deterministic order.
+func neighbours(el Element, kind ElementKind) []Element {
+	switch src := el.(type) {
+	case *Vertex:
+		if kind == EdgeElement {
+			return sortedByID(src.Edges)
+		}
+
+		if kind == FaceElement {
+			return sortedByID(src.Faces)
+		}
+	case *Edge:
+		if kind == VertexElement {
+			ends := []Element{src.V1, src.V2}
+			if src.V2.ID < src.V1.ID {
+				ends[0], ends[1] = ends[1], ends[0]
+			}
+
+			return ends
+		}
+
+		if kind == FaceElement {
+			return sortedByID(src.Faces)
+		}
+	case *Face:
+		if kind == VertexElement {
+			return vertexElements(src.Vertices)
+		}
+
+		if kind == EdgeElement {
+			return edgeElements(src.Edges)
+		}
+	}
+
+	return nil
+}
+
+// vertexElements converts vertices to elements, preserving order.
+func vertexElements(vertices []*Vertex) []Element {
+	elements := make([]Element, len(vertices))
+
+	for i, v := range vertices {
+		elements[i] = v
+	}
+
+	return elements
+}
+
+// edgeElements converts edges to elements, preserving order.
+func edgeElements(edges []*Edge) []Element {
+	elements := make([]Element, len(edges))
+
+	for i, e := range edges {
+		elements[i] = e
+	}
+
+	return elements
+}
+
+// propagateAttributes sets the attributes of every output element from its source
+// in the input, following each key's rule for the operation recorded in the
+// output's provenance. Existing attributes on the output are replaced.
+func propagateAttributes(input, output *Polyhedron) {
+	pr := output.Provenance
+	if pr == nil || !input.hasAttributes() {
+		return
+	}
+
+	for _, kind := range elementKinds() {
+		for id, src := range pr.elements(kind) {
+			target := output.element(Source{Kind: kind, ID: id})
+			if target == nil {
+				continue
+			}
+
+			*target.attributes() = propagatedAttributes(input.element(src), kind, pr.Operation)
+		}
+	}
+}
+
+// propagatedAttributes computes the attributes an element of the given kind
+// receives from its source element.
+func propagatedAttributes(source Element, kind ElementKind, operation string) attributeSet {
+	if source == nil {
+		return nil
+	}
+
+	var around []Element
+
+	if sourceKind(source) != kind {
+		around = neighbours(source, kind)
+	}
+
+	// Collect every key present on the source or, for blending, around it.
+	keys := make(map[string]*attributeKey)
+
+	for _, el := range append([]Element{source}, around...) {
+		for name, stored := range *el.attributes() {
+			keys[name] = stored.key
+		}
+	}
+
+	var result attributeSet
+
+	for name, key := range keys {
+		value, ok := propagatedValue(source, around, name, key, operation)
+		if !ok {
+			continue
+		}
+
+		if result == nil {
+			result = make(attributeSet, len(keys))
+		}
+
+		result[name] = attributeValue{value: value, key: key}
+	}
+
+	return result
+}
+
+// propagatedValue applies a key's rule to a single attribute.
+func propagatedValue(source Element, around []Element, name string, key *attributeKey, operation string) (any, bool) {
+	own, hasOwn := (*source.attributes())[name]
+
+	switch key.ruleFor(operation) {
+	case Inherit:
+		return own.value, hasOwn
+	case Blend:
+		values := make([]any, 0, len(around))
+
+		for _, el := range around {
+			if stored, ok := (*el.attributes())[name]; ok {
+				values = append(values, stored.value)
+			}
+		}
+
+		if len(values) == 0 {
+			return own.value, hasOwn
+		}
+
+		if key.blend == nil {
+			return values[0], true
+		}
+
+		return key.blend(values), true
+	case Reset:
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// sourceKind returns the kind of an element.
+func sourceKind(el Element) ElementKind {
+	switch el.(type) {
+	case *Edge:
+		return EdgeElement
+	case *Face:
+		return FaceElement
+	default:
+		return VertexElement
+	}
+}
+
+// cloneAttributes copies an attribute set; values are shared.
+func cloneAttributes(attrs attributeSet) attributeSet {
+	return maps.Clone(attrs)
+}
@@ -0,0 +1,217 @@
+package conway_test
+
+import (
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var red = conway.RGB(1, 0, 0)
+
+// lowestFace returns the face with the smallest ID.
+func lowestFace(p *conway.Polyhedron) *conway.Face {
+	var lowest *conway.Face
+
+	for _, f := range p.Faces {
+		if lowest == nil || f.ID < lowest.ID {
+			lowest = f
+		}
+	}
+
+	return lowest
+}
+
+// countColored returns how many faces carry the given colour.
+func countColored(p *conway.Polyhedron, key conway.AttributeKey[conway.Color], c conway.Color) int {
+	n := 0
+
+	for _, f := range p.Faces {
+		if got, ok := key.Get(f); ok && got == c {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestAttributeGetSetDelete(t *testing.T) {
+	t.Parallel()
+
+	key := conway.NewAttributeKey[string]("label", conway.Inherit)
+	v := conway.NewVertex(1, conway.Vector3{})
+
+	_, ok := key.Get(v)
+	assert.False(t, ok)
+
+	key.Set(v, "apex")
+
+	label, ok := key.Get(v)
+	require.True(t, ok)
+	assert.Equal(t, "apex", label)
+
+	// A key with the same name but another type does not see the value.
+	_, ok = conway.NewAttributeKey[int]("label", conway.Inherit).Get(v)
+	assert.False(t, ok)
+
+	key.Delete(v)
+
+	_, ok = key.Get(v)
+	assert.False(t, ok)
+}
+
+func TestAttributeInheritThroughKis(t *testing.T) {
+	t.Parallel()
+
+	color := conway.ColorAttribute()
+	cube := conway.Cube()
+	color.Set(lowestFace(cube), red)
+
+	kised := conway.Kis(cube)
+
+	// The four triangles raised on the red square stay red.
+	assert.Equal(t, 4, countColored(kised, color, red))
+	assert.Equal(t, 12, countColored(conway.Kis(kised), color, red))
+}
+
+func TestAttributeInheritFromVertices(t *testing.T) {
+	t.Parallel()
+
+	color := conway.ColorAttribute()
+	cube := conway.Cube()
+
+	for _, v := range cube.Vertices {
+		color.Set(v, red)
+	}
+
+	truncated := conway.Truncate(cube)
+
+	// Only the faces cut from the coloured vertices inherit; the shrunken squares do not.
+	assert.Equal(t, 8, countColored(truncated, color, red))
+}
+
+func TestAttributeBlend(t *testing.T) {
+	t.Parallel()
+
+	uv := conway.UVAttribute()
+	tetra := conway.Tetrahedron()
+
+	for _, v := range tetra.Vertices {
+		uv.Set(v, conway.UV{U: float64(v.ID), V: 0})
+	}
+
+	ambo := conway.Ambo(tetra)
+
+	// Each vertex of the ambo sits on an original edge and blends its endpoints.
+	for id, v := range ambo.Vertices {
+		src, ok := ambo.Provenance.Source(conway.VertexElement, id)
+		require.True(t, ok)
+
+		edge := tetra.Edges[src.ID]
+
+		got, ok := uv.Get(v)
+		require.True(t, ok)
+		assert.InDelta(t, float64(edge.V1.ID+edge.V2.ID)/2, got.U, 1e-12)
+	}
+}
+
+func TestAttributeBlendTags(t *testing.T) {
+	t.Parallel()
+
+	tags := conway.TagsAttribute().WithRule("d", conway.Blend)
+	cube := conway.Cube()
+	first := lowestFace(cube)
+
+	for _, f := range cube.Faces {
+		tags.Set(f, []string{"side"})
+	}
+
+	tags.Set(first, []string{"top", "side"})
+
+	dual := conway.Dual(cube)
+
+	// Dual vertices come from faces and keep their tags; the dual faces come from
+	// vertices and take the union of the tags around them.
+	withTop := 0
+
+	for _, f := range dual.Faces {
+		got, ok := tags.Get(f)
+		require.True(t, ok)
+
+		if len(got) == 2 {
+			assert.Equal(t, []string{"side", "top"}, got)
+
+			withTop++
+		}
+	}
+
+	assert.Equal(t, 4, withTop)
+}
+
+func TestAttributeRules(t *testing.T) {
+	t.Parallel()
+
+	color := conway.ColorAttribute().WithRule("k", conway.Reset)
+
+	assert.Equal(t, conway.Reset, color.Rule("k"))
+	assert.Equal(t, conway.Inherit, color.Rule("t"))
+	assert.Equal(t, conway.Inherit, conway.ColorAttribute().Rule("k"), "WithRule must not modify the original key")
+	assert.Equal(t, "blend", conway.Blend.String())
+
+	cube := conway.Cube()
+	color.Set(lowestFace(cube), red)
+
+	assert.Equal(t, 0, countColored(conway.Kis(cube), color, red))
+	assert.Equal(t, 1, countColored(conway.Truncate(cube), color, red))
+
+	// Compound operations look up their own symbol.
+	joinReset := conway.ColorAttribute().WithRule("j", conway.Reset)
+	joinReset.Set(lowestFace(cube), red)
+
+	assert.Equal(t, 0, countColored(conway.Join(cube), joinReset, red))
+	assert.Equal(t, 1, countColored(conway.Expand(cube), joinReset, red))
+}
+
+func TestAttributesSurviveClone(t *testing.T) {
+	t.Parallel()
+
+	color := conway.ColorAttribute()
+	cube := conway.Cube()
+	face := lowestFace(cube)
+	color.Set(face, red)
+
+	for _, e := range face.Edges {
+		color.Set(e, red)
+	}
+
+	clone := cube.Clone()
+
+	assert.Equal(t, 1, countColored(clone, color, red))
+
+	coloredEdges := 0
+
+	for _, e := range clone.Edges {
+		if _, ok := color.Get(e); ok {
+			coloredEdges++
+		}
+	}
+
+	assert.Equal(t, 4, coloredEdges)
+
+	// The clone's attributes are independent of the original's.
+	color.Delete(lowestFace(clone))
+	assert.Equal(t, 1, countColored(cube, color, red))
+}
+
+func TestColor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "#ff8000", conway.RGB(1, 0.5, 0).Hex())
+	assert.Equal(t, "#000000", conway.RGB(-1, 0, 0).Hex())
+
+	r, g, b, a := conway.Color{R: 1, G: 0.5, B: 0, A: 0.5}.RGBA()
+	assert.Equal(t, []uint32{0x8000, 0x4000, 0, 0x8000}, []uint32{r, g, b, a})
+
+	assert.Equal(t, conway.RGB(0.5, 0.5, 0), conway.BlendColors([]conway.Color{conway.RGB(1, 0, 0), conway.RGB(0, 1, 0)}))
+}
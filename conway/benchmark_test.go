@@ -355,3 +355,66 @@ func BenchmarkScalability(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkParallelOperations compares serial and parallel builds on large inputs.
+func BenchmarkParallelOperations(b *testing.B) {
+	large := conway.MustParse("tkatI") // 1,080 vertices, 542 faces
+
+	workerCounts := []struct {
+		name    string
+		workers int
+	}{
+		{"Serial", 1},
+		{"Workers2", 2},
+		{"Workers4", 4},
+		{"AllCPUs", 0},
+	}
+
+	for _, wc := range workerCounts {
+		operations := []conway.Operation{
+			conway.DualOp{Workers: wc.workers},
+			conway.AmboOp{Workers: wc.workers},
+			conway.TruncateOp{Workers: wc.workers},
+			conway.KisOp{Workers: wc.workers},
+		}
+
+		for _, op := range operations {
+			b.Run(op.Name()+"_"+wc.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_ = op.Apply(large)
+				}
+			})
+		}
+
+		b.Run("Chain_ktkatI_"+wc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = conway.Parse("ktkatI", conway.WithWorkers(wc.workers))
+			}
+		})
+	}
+}
+
+// BenchmarkEvaluator compares evaluating a gallery of related notations with and without caching.
+func BenchmarkEvaluator(b *testing.B) {
+	gallery := []string{"tkI", "dtkI", "atkI", "ktkI", "jtkI"}
+
+	b.Run("Parse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, notation := range gallery {
+				_, _ = conway.Parse(notation)
+			}
+		}
+	})
+
+	b.Run("Evaluator", func(b *testing.B) {
+		evaluator := conway.NewEvaluator(0)
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for _, notation := range gallery {
+				_, _ = evaluator.Evaluate(notation)
+			}
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package conway
+
+import "slices"
+
+// meshBuilder collects the output of an operation in pre-sized, index-based buffers.
+// Operations fill disjoint slots of positions and faces from concurrent workers
+// without touching the polyhedron's maps or mutex, then call build once to
+// assemble the final polyhedron.
+type meshBuilder struct {
+	positions  []Vector3 // Vertex positions, indexed by output vertex
+	faces      [][]int   // Face boundaries as vertex indices; nil slots are skipped
+	extraEdges [][2]int  // Edges to create even if no face references them
+	wound      bool      // Faces are already wound outwards and are not corrected
+
+	// Provenance tracking, enabled by trace.
+	operation     string                // Symbol recorded in the provenance step
+	input         *Polyhedron           // Operation input, source of provenance and attributes
+	vertexSources []Source              // Source of each output vertex, indexed like positions
+	faceSources   []Source              // Source of each face slot, indexed like faces
+	edgeSource    func(a, b int) Source // Source of the edge joining two output vertex indices
+}
+
+// newMeshBuilder creates a builder with room for the given number of vertices and faces.
+func newMeshBuilder(vertexCount, faceCount int) *meshBuilder {
+	return &meshBuilder{
+		positions:  make([]Vector3, vertexCount),
+		faces:      make([][]int, faceCount),
+		extraEdges: nil,
+		wound:      false,
+
+		operation:     "",
+		input:         nil,
+		vertexSources: nil,
+		faceSources:   nil,
+		edgeSource:    nil,
+	}
+}
+
+// trace enables provenance tracking and attribute propagation. The operation
+// fills vertexSources and faceSources alongside positions and faces; edge
+// sources are derived from their endpoints once the edges exist.
+func (b *meshBuilder) trace(operation string, input *Polyhedron, edgeSource func(a, b int) Source) {
+	b.operation = operation
+	b.input = input
+	b.vertexSources = make([]Source, len(b.positions))
+	b.faceSources = make([]Source, len(b.faces))
+	b.edgeSource = edgeSource
+}
+
+// center returns the average of all vertex positions.
+func (b *meshBuilder) center() Vector3 {
+	sum := Vector3{X: 0, Y: 0, Z: 0}
+
+	if len(b.positions) == 0 {
+		return sum
+	}
+
+	for _, pos := range b.positions {
+		sum = sum.Add(pos)
+	}
+
+	return sum.Scale(1.0 / float64(len(b.positions)))
+}
+
+// build assembles the collected vertices and faces into a new polyhedron.
+// Unless the faces are already wound, their winding is corrected in parallel using
+// the centroid rule and then made consistent across shared edges, after which
+// vertices, edges and faces are linked in a single pass.
+func (b *meshBuilder) build(name string, workers int) *Polyhedron {
+	p := NewPolyhedron(name)
+
+	p.Vertices = make(map[int]*Vertex, len(b.positions))
+	p.Faces = make(map[int]*Face, len(b.faces))
+
+	vertices := make([]*Vertex, len(b.positions))
+
+	for i, pos := range b.positions {
+		v := NewVertex(p.getNextID(), pos)
+
+		vertices[i] = v
+		p.Vertices[v.ID] = v
+	}
+
+	oriented := b.orientFaces(vertices, workers)
+
+	for _, pair := range b.extraEdges {
+		p.addEdgeUnsafe(vertices[pair[0]], vertices[pair[1]])
+	}
+
+	faces := make([]*Face, len(oriented))
+
+	for i, faceVertices := range oriented {
+		if faceVertices == nil {
+			continue
+		}
+
+		faces[i] = NewFace(p.getNextID(), faceVertices)
+		p.linkFaceUnsafe(faces[i])
+	}
+
+	if b.vertexSources != nil {
+		p.Provenance = b.provenance(vertices, faces, p.Edges)
+		propagateAttributes(b.input, p)
+	}
+
+	return p
+}
+
+// provenance records the sources of the built elements as a provenance step.
+func (b *meshBuilder) provenance(vertices []*Vertex, faces []*Face, edges map[int]*Edge) *Provenance {
+	prov := newProvenance(b.operation, b.input.Provenance)
+	index := indexVertices(vertices)
+
+	for i, v := range vertices {
+		prov.Vertices[v.ID] = b.vertexSources[i]
+	}
+
+	for i, f := range faces {
+		if f != nil {
+			prov.Faces[f.ID] = b.faceSources[i]
+		}
+	}
+
+	for _, e := range edges {
+		prov.Edges[e.ID] = b.edgeSource(index[e.V1.ID], index[e.V2.ID])
+	}
+
+	return prov
+}
+
+// edgeBetween returns the edge joining two vertices, or nil if they are not adjacent.
+func edgeBetween(v1, v2 *Vertex) *Edge {
+	for _, e := range v1.Edges {
+		if other := e.OtherVertex(v1); other != nil && other.ID == v2.ID {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// orientFaces resolves face indices to vertices and applies EnsureCounterClockwise.
+func (b *meshBuilder) orientFaces(vertices []*Vertex, workers int) [][]*Vertex {
+	oriented := make([][]*Vertex, len(b.faces))
+
+	// Match AddFace, which only corrects winding once there is a meaningful center.
+	correct := !b.wound && len(vertices) > 3
+	center := b.center()
+
+	parallelFor(workers, len(b.faces), func(start, end int) {
+		for i := start; i < end; i++ {
+			indices := b.faces[i]
+			if indices == nil {
+				continue
+			}
+
+			faceVertices := make([]*Vertex, len(indices))
+
+			for j, idx := range indices {
+				faceVertices[j] = vertices[idx]
+			}
+
+			if correct {
+				faceVertices = EnsureCounterClockwise(faceVertices, center)
+			}
+
+			oriented[i] = faceVertices
+		}
+	})
+
+	if correct {
+		propagateOrientation(b.positions, vertices, oriented)
+	}
+
+	return oriented
+}
+
+// propagateOrientation makes the winding of faces corrected one by one consistent
+// across their shared edges, as AddFace does, so faces that the centroid rule turns
+// inwards on non-convex shapes follow their neighbours instead. Closed components are
+// turned to enclose positive volume, or negative volume if they are cavities.
+func propagateOrientation(positions []Vector3, vertices []*Vertex, oriented [][]*Vertex) {
+	index := indexVertices(vertices)
+	slots := make([]int, 0, len(oriented))
+	boundaries := make([][]int, 0, len(oriented))
+
+	for i, faceVertices := range oriented {
+		if faceVertices == nil {
+			continue
+		}
+
+		boundary := make([]int, len(faceVertices))
+
+		for j, v := range faceVertices {
+			boundary[j] = index[v.ID]
+		}
+
+		slots = append(slots, i)
+		boundaries = append(boundaries, boundary)
+	}
+
+	flipped, _ := orientFaces(positions, boundaries)
+
+	for i, flip := range flipped {
+		if flip {
+			slices.Reverse(oriented[slots[i]])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package conway
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// colorChannelMax is the largest 8-bit channel value.
+	colorChannelMax = 255
+	// colorAlphaMax is the largest 16-bit channel value used by image/color.
+	colorAlphaMax = 0xffff
+)
+
+// Color is a linear RGBA colour with channels in [0, 1]. It implements
+// image/color.Color so it can be used directly with the standard image packages.
+type Color struct {
+	R, G, B, A float64
+}
+
+// RGB returns an opaque colour from channels in [0, 1].
+func RGB(r, g, b float64) Color {
+	return Color{R: r, G: g, B: b, A: 1}
+}
+
+// clampChannel limits a channel value to [0, 1].
+func clampChannel(c float64) float64 {
+	return math.Max(0, math.Min(1, c))
+}
+
+// RGBA implements image/color.Color, returning alpha-premultiplied 16-bit channels.
+func (c Color) RGBA() (uint32, uint32, uint32, uint32) {
+	a := clampChannel(c.A)
+
+	channel := func(v float64) uint32 {
+		return uint32(math.Round(clampChannel(v) * a * colorAlphaMax))
+	}
+
+	return channel(c.R), channel(c.G), channel(c.B), uint32(math.Round(a * colorAlphaMax))
+}
+
+// Hex returns the colour as "#rrggbb", ignoring alpha.
+func (c Color) Hex() string {
+	channel := func(v float64) int {
+		return int(math.Round(clampChannel(v) * colorChannelMax))
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", channel(c.R), channel(c.G), channel(c.B))
+}
+
+// BlendColors returns the channel-wise average of the colours.
+func BlendColors(colors []Color) Color {
+	if len(colors) == 0 {
+		return Color{R: 0, G: 0, B: 0, A: 0}
+	}
+
+	var sum Color
+
+	for _, c := range colors {
+		sum.R += c.R
+		sum.G += c.G
+		sum.B += c.B
+		sum.A += c.A
+	}
+
+	n := float64(len(colors))
+
+	return Color{R: sum.R / n, G: sum.G / n, B: sum.B / n, A: sum.A / n}
+}
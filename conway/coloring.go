@@ -0,0 +1,609 @@
+package conway
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+const (
+	// properColoringStepLimit bounds the backtracking search for a smaller proper
+	// colouring. When it is reached, the best colouring found so far is kept.
+	properColoringStepLimit = 200000
+	// exactColoringMaxFaces is the largest face count searched exhaustively.
+	exactColoringMaxFaces = 128
+	// localRecolorMaxRadius is how far around a face local recolouring may reach.
+	localRecolorMaxRadius = 4
+	// paletteHueStep spreads generated palette hues by the golden angle in turns.
+	paletteHueStep = 0.618033988749895
+	// paletteSaturation and paletteValue are used for generated palette colours.
+	paletteSaturation = 0.55
+	paletteValue      = 0.9
+	// hueSectors is the number of sectors in the HSV colour wheel.
+	hueSectors = 6
+)
+
+// Palette is an ordered list of colours indexed by colour class.
+type Palette []Color
+
+// DefaultPalette returns a palette of twelve distinct, print-friendly colours.
+func DefaultPalette() Palette {
+	return Palette{
+		RGB(0.902, 0.294, 0.208), // Red
+		RGB(0.302, 0.733, 0.835), // Cyan
+		RGB(0.000, 0.627, 0.529), // Teal
+		RGB(0.941, 0.737, 0.282), // Yellow
+		RGB(0.235, 0.329, 0.533), // Navy
+		RGB(0.953, 0.608, 0.498), // Salmon
+		RGB(0.518, 0.569, 0.706), // Slate
+		RGB(0.569, 0.820, 0.761), // Mint
+		RGB(0.494, 0.380, 0.282), // Brown
+		RGB(0.863, 0.000, 0.000), // Crimson
+		RGB(0.690, 0.612, 0.522), // Sand
+		RGB(0.557, 0.306, 0.635), // Purple
+	}
+}
+
+// Color returns the colour for a class. Classes beyond the palette get generated
+// colours, so any number of classes can be coloured distinctly.
+func (pl Palette) Color(class int) Color {
+	if class >= 0 && class < len(pl) {
+		return pl[class]
+	}
+
+	hue := math.Mod(float64(class)*paletteHueStep, 1)
+
+	return hsv(hue, paletteSaturation, paletteValue)
+}
+
+// hsv converts a hue in turns, saturation and value to an opaque colour.
+func hsv(h, s, v float64) Color {
+	sector := h * hueSectors
+	i := math.Floor(sector)
+	f := sector - i
+	p, q, t := v*(1-s), v*(1-s*f), v*(1-s*(1-f))
+
+	switch int(i) % hueSectors {
+	case 0:
+		return RGB(v, t, p)
+	case 1:
+		return RGB(q, v, p)
+	case 2:
+		return RGB(p, v, t)
+	case 3:
+		return RGB(p, q, v)
+	case 4:
+		return RGB(t, p, v)
+	default:
+		return RGB(v, p, q)
+	}
+}
+
+// Coloring assigns each face of a polyhedron to a colour class.
+type Coloring struct {
+	Classes map[int]int // Face ID to colour class, numbered from 0
+	Count   int         // Number of colour classes
+}
+
+// Apply writes the colour of each face's class into its ColorAttribute.
+func (c Coloring) Apply(p *Polyhedron, palette Palette) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	key := ColorAttribute()
+
+	for id, class := range c.Classes {
+		if f, ok := p.Faces[id]; ok {
+			key.Set(f, palette.Color(class))
+		}
+	}
+}
+
+// IsProper reports whether no two faces sharing an edge are in the same class.
+func (c Coloring) IsProper(p *Polyhedron) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, e := range p.Edges {
+		seen := make(map[int]bool, len(e.Faces))
+
+		for id := range e.Faces {
+			class := c.Classes[id]
+			if seen[class] {
+				return false
+			}
+
+			seen[class] = true
+		}
+	}
+
+	return true
+}
+
+// coloringByKey numbers faces by an ordered key, giving equal keys the same class
+// and numbering classes in key order.
+func coloringByKey[K cmp.Ordered](faces []*Face, key func(i int, f *Face) K) Coloring {
+	keys := make([]K, len(faces))
+
+	for i, f := range faces {
+		keys[i] = key(i, f)
+	}
+
+	distinct := slices.Compact(slices.Sorted(slices.Values(keys)))
+	coloring := Coloring{Classes: make(map[int]int, len(faces)), Count: len(distinct)}
+
+	for i, f := range faces {
+		class, _ := slices.BinarySearch(distinct, keys[i])
+		coloring.Classes[f.ID] = class
+	}
+
+	return coloring
+}
+
+// DegreeColoring colours faces by their number of sides, with classes in order of
+// increasing degree.
+func DegreeColoring(p *Polyhedron) Coloring {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return coloringByKey(sortedFaces(p), func(_ int, f *Face) int { return f.Degree() })
+}
+
+// SymmetryColoring colours faces by their orbit under the polyhedron's symmetries,
+// so faces that the symmetry group maps onto each other share a colour. Classes are
+// ordered by face degree, then by lowest face ID.
+func SymmetryColoring(p *Polyhedron) Coloring {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	faces := sortedFaces(p)
+	orbits, _ := symmetryOrbits(p)
+
+	// Orbits are numbered in order of their lowest face, so degree and orbit sort the classes.
+	return coloringByKey(faces, func(i int, f *Face) int { return f.Degree()*len(faces) + orbits[i] })
+}
+
+// SourceColoring colours faces by the kind of seed element they were derived from,
+// following the polyhedron's provenance. Classes are ordered vertex, edge, face;
+// a seed has a single class.
+func SourceColoring(p *Polyhedron) Coloring {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	resolved := p.Provenance.Resolve()
+
+	return coloringByKey(sortedFaces(p), func(_ int, f *Face) ElementKind {
+		if src, ok := resolved.Source(FaceElement, f.ID); ok {
+			return src.Kind
+		}
+
+		return FaceElement
+	})
+}
+
+// ProperColoring colours faces so that no two faces sharing an edge have the same
+// colour, using as few colours as it can find. A greedy largest-first colouring and
+// DSATUR provide an initial colouring. Colours are then removed one at a time by
+// recolouring along Kempe chains, falling back to a backtracking search over a
+// widening neighbourhood of each face. Polyhedra with up to exactColoringMaxFaces
+// faces finish with a backtracking search over all faces for fewer colours. Every
+// search is bounded by properColoringStepLimit steps, so the result is the best found
+// rather than guaranteed optimal. The result is deterministic.
+func ProperColoring(p *Polyhedron) Coloring {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	faces := sortedFaces(p)
+	adj := faceAdjacency(p, faces)
+	lower := coloringLowerBound(adj)
+
+	best := greedyColoring(adj)
+	if dsatur := dsaturColoring(adj); colorCount(dsatur) < colorCount(best) {
+		best = dsatur
+	}
+
+	for colorCount(best) > lower {
+		reduced, ok := reduceColoring(adj, best)
+		if !ok {
+			break
+		}
+
+		best = reduced
+	}
+
+	if len(faces) <= exactColoringMaxFaces {
+		budget := properColoringStepLimit
+
+		for target := colorCount(best) - 1; target >= lower; target-- {
+			found, ok := backtrackColoring(adj, target, &budget)
+			if !ok {
+				break
+			}
+
+			best = found
+		}
+	}
+
+	coloring := Coloring{Classes: make(map[int]int, len(faces)), Count: colorCount(best)}
+
+	for i, f := range faces {
+		coloring.Classes[f.ID] = best[i]
+	}
+
+	return coloring
+}
+
+// faceAdjacency returns, for each face index, the indices of the faces sharing an edge with it.
+func faceAdjacency(p *Polyhedron, faces []*Face) [][]int {
+	index := indexFaces(faces)
+	adj := make([][]int, len(faces))
+
+	for i, f := range faces {
+		for _, e := range f.Edges {
+			for id := range e.Faces {
+				if j := index[id]; j != i && !slices.Contains(adj[i], j) {
+					adj[i] = append(adj[i], j)
+				}
+			}
+		}
+
+		slices.Sort(adj[i])
+	}
+
+	return adj
+}
+
+// colorCount returns the number of colours used by an assignment.
+func colorCount(colors []int) int {
+	if len(colors) == 0 {
+		return 0
+	}
+
+	return slices.Max(colors) + 1
+}
+
+// smallestFreeColor returns the lowest colour not used by any coloured neighbour.
+func smallestFreeColor(adj [][]int, colors []int, i int) int {
+	used := make(map[int]bool, len(adj[i]))
+
+	for _, j := range adj[i] {
+		if colors[j] >= 0 {
+			used[colors[j]] = true
+		}
+	}
+
+	c := 0
+	for used[c] {
+		c++
+	}
+
+	return c
+}
+
+// uncolored returns an assignment with every face uncoloured.
+func uncolored(n int) []int {
+	colors := make([]int, n)
+
+	for i := range colors {
+		colors[i] = -1
+	}
+
+	return colors
+}
+
+// greedyColoring colours faces in order of decreasing adjacency (Welsh-Powell).
+func greedyColoring(adj [][]int) []int {
+	order := make([]int, len(adj))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(len(adj[b]), len(adj[a])) })
+
+	colors := uncolored(len(adj))
+
+	for _, i := range order {
+		colors[i] = smallestFreeColor(adj, colors, i)
+	}
+
+	return colors
+}
+
+// saturation returns the number of distinct colours among a face's neighbours.
+func saturation(adj [][]int, colors []int, i int) int {
+	distinct := make(map[int]bool, len(adj[i]))
+
+	for _, j := range adj[i] {
+		if colors[j] >= 0 {
+			distinct[colors[j]] = true
+		}
+	}
+
+	return len(distinct)
+}
+
+// mostSaturated returns the uncoloured face among candidates with the most distinctly
+// coloured neighbours, breaking ties by adjacency and then by order. It returns -1
+// when every candidate is coloured.
+func mostSaturated(adj [][]int, colors []int, candidates []int) int {
+	best, bestSat := -1, -1
+
+	for _, i := range candidates {
+		if colors[i] >= 0 {
+			continue
+		}
+
+		sat := saturation(adj, colors, i)
+		if sat > bestSat || (sat == bestSat && len(adj[i]) > len(adj[best])) {
+			best, bestSat = i, sat
+		}
+	}
+
+	return best
+}
+
+// allFaces returns the indices 0 to n-1.
+func allFaces(n int) []int {
+	faces := make([]int, n)
+
+	for i := range faces {
+		faces[i] = i
+	}
+
+	return faces
+}
+
+// dsaturColoring colours the most constrained face first (Brélaz's DSATUR).
+func dsaturColoring(adj [][]int) []int {
+	colors := uncolored(len(adj))
+	faces := allFaces(len(adj))
+
+	for i := mostSaturated(adj, colors, faces); i >= 0; i = mostSaturated(adj, colors, faces) {
+		colors[i] = smallestFreeColor(adj, colors, i)
+	}
+
+	return colors
+}
+
+// coloringLowerBound returns 1 without adjacencies, 2 for bipartite face graphs and 3 otherwise.
+func coloringLowerBound(adj [][]int) int {
+	const minNonBipartite = 3
+
+	hasEdge := false
+	side := uncolored(len(adj))
+
+	for start := range adj {
+		if side[start] >= 0 {
+			continue
+		}
+
+		side[start] = 0
+		queue := []int{start}
+
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+
+			for _, j := range adj[i] {
+				hasEdge = true
+
+				if side[j] < 0 {
+					side[j] = 1 - side[i]
+					queue = append(queue, j)
+				} else if side[j] == side[i] {
+					return minNonBipartite
+				}
+			}
+		}
+	}
+
+	if hasEdge {
+		return 2
+	}
+
+	return 1
+}
+
+// backtrackColoring searches for a colouring with at most k colours. It returns
+// false if none exists or the step budget runs out first.
+func backtrackColoring(adj [][]int, k int, budget *int) ([]int, bool) {
+	colors := uncolored(len(adj))
+
+	if !backtrack(adj, colors, allFaces(len(adj)), k, budget) {
+		return nil, false
+	}
+
+	return colors, true
+}
+
+// backtrack colours the uncoloured faces among candidates with colours below k,
+// choosing the most saturated face at each step. Colours already assigned are kept.
+// On failure the candidates are left uncoloured.
+func backtrack(adj [][]int, colors []int, candidates []int, k int, budget *int) bool {
+	i := mostSaturated(adj, colors, candidates)
+	if i < 0 {
+		return true
+	}
+
+	highest := colorCount(colors)
+
+	for c := range k {
+		if *budget <= 0 {
+			return false
+		}
+
+		*budget--
+
+		if !colorAvailable(adj, colors, i, c) {
+			continue
+		}
+
+		colors[i] = c
+
+		if backtrack(adj, colors, candidates, k, budget) {
+			return true
+		}
+
+		colors[i] = -1
+
+		// Colours above the highest used so far are interchangeable; trying one is enough.
+		if c >= highest {
+			break
+		}
+	}
+
+	return false
+}
+
+// colorAvailable reports whether no neighbour of face i has colour c.
+func colorAvailable(adj [][]int, colors []int, i, c int) bool {
+	for _, j := range adj[i] {
+		if colors[j] == c {
+			return false
+		}
+	}
+
+	return true
+}
+
+// reduceColoring tries to remove the highest colour from a proper colouring. Each
+// face with that colour is moved to a free lower colour, to one freed by swapping a
+// Kempe chain, or failing both, recoloured together with its neighbourhood.
+func reduceColoring(adj [][]int, colors []int) ([]int, bool) {
+	target := colorCount(colors) - 1
+	reduced := slices.Clone(colors)
+	budget := properColoringStepLimit
+
+	for i := range reduced {
+		if reduced[i] != target {
+			continue
+		}
+
+		reduced[i] = -1
+
+		if c := smallestFreeColor(adj, reduced, i); c < target {
+			reduced[i] = c
+		} else if c, ok := kempeRecolor(adj, reduced, i, target); ok {
+			reduced[i] = c
+		} else if !localRecolor(adj, reduced, i, target, &budget) {
+			return nil, false
+		}
+	}
+
+	return reduced, true
+}
+
+// localRecolor colours face i with fewer than k colours by searching again over the
+// faces around it, widening the neighbourhood until a colouring is found. Faces
+// outside the neighbourhood keep their colours.
+func localRecolor(adj [][]int, colors []int, i, k int, budget *int) bool {
+	for radius := 1; radius <= localRecolorMaxRadius; radius++ {
+		ball := facesWithin(adj, i, radius)
+		saved := make([]int, len(ball))
+
+		for j, f := range ball {
+			saved[j] = colors[f]
+			colors[f] = -1
+		}
+
+		if backtrack(adj, colors, ball, k, budget) {
+			return true
+		}
+
+		for j, f := range ball {
+			colors[f] = saved[j]
+		}
+
+		colors[i] = -1
+	}
+
+	return false
+}
+
+// facesWithin returns face i and every face at most radius adjacency steps from it.
+func facesWithin(adj [][]int, i, radius int) []int {
+	distance := map[int]int{i: 0}
+	ball := []int{i}
+
+	for next := 0; next < len(ball); next++ {
+		f := ball[next]
+		if distance[f] == radius {
+			continue
+		}
+
+		for _, n := range adj[f] {
+			if _, seen := distance[n]; !seen {
+				distance[n] = distance[f] + 1
+				ball = append(ball, n)
+			}
+		}
+	}
+
+	return ball
+}
+
+// kempeRecolor frees a colour below k for the uncoloured face i by swapping the
+// colours of a Kempe chain: the faces reachable from i's a-coloured neighbours
+// through faces coloured a or b. If that chain contains none of i's b-coloured
+// neighbours, swapping a and b on it leaves a unused around i.
+func kempeRecolor(adj [][]int, colors []int, i, k int) (int, bool) {
+	for a := range k {
+		for b := range k {
+			if a == b {
+				continue
+			}
+
+			if chain, ok := kempeChain(adj, colors, i, a, b); ok {
+				for _, j := range chain {
+					if colors[j] == a {
+						colors[j] = b
+					} else {
+						colors[j] = a
+					}
+				}
+
+				return a, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// kempeChain returns the (a, b) chain grown from the a-coloured neighbours of face i,
+// or false if it reaches a b-coloured neighbour of i.
+func kempeChain(adj [][]int, colors []int, i, a, b int) ([]int, bool) {
+	inChain := make(map[int]bool)
+	queue := make([]int, 0)
+
+	for _, j := range adj[i] {
+		if colors[j] == a {
+			inChain[j] = true
+			queue = append(queue, j)
+		}
+	}
+
+	chain := slices.Clone(queue)
+
+	for len(queue) > 0 {
+		j := queue[0]
+		queue = queue[1:]
+
+		for _, n := range adj[j] {
+			if n == i || inChain[n] || (colors[n] != a && colors[n] != b) {
+				continue
+			}
+
+			if colors[n] == b && slices.Contains(adj[i], n) {
+				return nil, false
+			}
+
+			inChain[n] = true
+			chain = append(chain, n)
+			queue = append(queue, n)
+		}
+	}
+
+	return chain, true
+}
@@ -0,0 +1,151 @@
+package conway_test
+
+import (
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// classSizes returns the number of faces in each colour class.
+func classSizes(c conway.Coloring) []int {
+	sizes := make([]int, c.Count)
+
+	for _, class := range c.Classes {
+		sizes[class]++
+	}
+
+	return sizes
+}
+
+func TestDegreeColoring(t *testing.T) {
+	t.Parallel()
+
+	c := conway.DegreeColoring(conway.MustParse("tC"))
+
+	assert.Equal(t, 2, c.Count)
+	assert.Equal(t, []int{8, 6}, classSizes(c), "triangles first, then octagons")
+}
+
+func TestSymmetryColoring(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		notation string
+		sizes    []int
+	}{
+		{"C", []int{6}},
+		{"I", []int{20}},
+		{"kD", []int{60}},
+		{"tI", []int{20, 12}},
+		// The rhombicuboctahedron has two orbits of squares: six on the axes and twelve on the edges.
+		{"eC", []int{8, 6, 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(tt.notation)
+			c := conway.SymmetryColoring(p)
+
+			assert.Equal(t, len(tt.sizes), c.Count)
+			assert.ElementsMatch(t, tt.sizes, classSizes(c))
+			assert.LessOrEqual(t, conway.DegreeColoring(p).Count, c.Count)
+		})
+	}
+}
+
+func TestSourceColoring(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, conway.SourceColoring(conway.Cube()).Count)
+	assert.Equal(t, 1, conway.SourceColoring(conway.MustParse("kC")).Count)
+	assert.Equal(t, []int{8, 6}, classSizes(conway.SourceColoring(conway.MustParse("tC"))))
+	assert.Equal(t, []int{8, 12, 6}, classSizes(conway.SourceColoring(conway.MustParse("eC"))))
+}
+
+func TestProperColoring(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		notation string
+		colors   int
+	}{
+		{"T", 4},
+		{"C", 3},
+		{"O", 2},
+		{"D", 4},
+		{"I", 3},
+		{"aC", 2},
+		{"kC", 2},
+		{"tC", 4},
+		{"tkatI", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(tt.notation)
+			c := conway.ProperColoring(p)
+
+			assert.True(t, c.IsProper(p))
+			assert.Len(t, c.Classes, len(p.Faces))
+			assert.Equal(t, tt.colors, c.Count)
+			assert.Equal(t, c, conway.ProperColoring(p), "colouring must be deterministic")
+		})
+	}
+}
+
+func TestColoringIsProper(t *testing.T) {
+	t.Parallel()
+
+	cube := conway.Cube()
+
+	assert.False(t, conway.DegreeColoring(cube).IsProper(cube))
+}
+
+func TestColoringApply(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("tC")
+	palette := conway.DefaultPalette()
+
+	conway.DegreeColoring(p).Apply(p, palette)
+
+	color := conway.ColorAttribute()
+
+	for _, f := range p.Faces {
+		got, ok := color.Get(f)
+		require.True(t, ok)
+
+		if f.Degree() == 3 {
+			assert.Equal(t, palette[0], got)
+		} else {
+			assert.Equal(t, palette[1], got)
+		}
+	}
+
+	// Colours follow the faces through later operations.
+	assert.Equal(t, 8*3, countColored(conway.Kis(p), color, palette[0]))
+}
+
+func TestPaletteColor(t *testing.T) {
+	t.Parallel()
+
+	palette := conway.DefaultPalette()
+	seen := make(map[conway.Color]bool)
+
+	for class := range 3 * len(palette) {
+		c := palette.Color(class)
+
+		assert.False(t, seen[c], "class %d repeats a colour", class)
+		assert.InDelta(t, 1.0, c.A, 0)
+
+		seen[c] = true
+	}
+
+	assert.Equal(t, conway.RGB(0.5, 0.5, 0.5), conway.Palette{conway.RGB(0.5, 0.5, 0.5)}.Color(0))
+}
@@ -1,6 +1,9 @@
 package conway
 
-type OrthoOp struct{}
+// OrthoOp is the double join. Workers is passed to the underlying operations.
+type OrthoOp struct {
+	Workers int
+}
 
 func (o OrthoOp) Symbol() string {
 	return "o"
@@ -11,10 +14,19 @@ func (o OrthoOp) Name() string {
 }
 
 func (o OrthoOp) Apply(p *Polyhedron) *Polyhedron {
-	return Join(Join(p))
+	join := JoinOp{Workers: o.Workers}
+
+	ortho := join.Apply(join.Apply(p))
+	ortho.Provenance = ortho.Provenance.collapse(2, o.Symbol())
+	propagateAttributes(p, ortho)
+
+	return ortho
 }
 
-type ExpandOp struct{}
+// ExpandOp is the double ambo. Workers is passed to the underlying operations.
+type ExpandOp struct {
+	Workers int
+}
 
 func (e ExpandOp) Symbol() string {
 	return "e"
@@ -25,10 +37,19 @@ func (e ExpandOp) Name() string {
 }
 
 func (e ExpandOp) Apply(p *Polyhedron) *Polyhedron {
-	return Ambo(Ambo(p))
+	ambo := AmboOp{Workers: e.Workers}
+
+	expand := ambo.Apply(ambo.Apply(p))
+	expand.Provenance = expand.Provenance.collapse(2, e.Symbol())
+	propagateAttributes(p, expand)
+
+	return expand
 }
 
-type GyroOp struct{}
+// GyroOp is the dual of ambo. Workers is passed to the underlying operations.
+type GyroOp struct {
+	Workers int
+}
 
 func (g GyroOp) Symbol() string {
 	return "g"
@@ -39,10 +60,17 @@ func (g GyroOp) Name() string {
 }
 
 func (g GyroOp) Apply(p *Polyhedron) *Polyhedron {
-	return Dual(Ambo(p))
+	gyro := DualOp{Workers: g.Workers}.Apply(AmboOp{Workers: g.Workers}.Apply(p))
+	gyro.Provenance = gyro.Provenance.collapse(2, g.Symbol())
+	propagateAttributes(p, gyro)
+
+	return gyro
 }
 
-type SnubOp struct{}
+// SnubOp is the dual of gyro. Workers is passed to the underlying operations.
+type SnubOp struct {
+	Workers int
+}
 
 func (s SnubOp) Symbol() string {
 	return "s"
@@ -53,7 +81,11 @@ func (s SnubOp) Name() string {
 }
 
 func (s SnubOp) Apply(p *Polyhedron) *Polyhedron {
-	return Dual(Gyro(p))
+	snub := DualOp{Workers: s.Workers}.Apply(GyroOp{Workers: s.Workers}.Apply(p))
+	snub.Provenance = snub.Provenance.collapse(2, s.Symbol())
+	propagateAttributes(p, snub)
+
+	return snub
 }
 
 func Ortho(p *Polyhedron) *Polyhedron {
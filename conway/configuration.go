@@ -0,0 +1,157 @@
+package conway
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// VertexConfigurations classifies a polyhedron by its vertex figures: the degrees of
+// the faces around each vertex, in order, such as "5.6.6" for the truncated
+// icosahedron or "3.4.3.4" for the cuboctahedron.
+type VertexConfigurations struct {
+	// Vertices holds the configuration of each vertex by ID. The sequence is read
+	// around the vertex from the rotation and reflection that gives the smallest
+	// numbers first; an open boundary appears as a 0.
+	Vertices map[int]string
+	// Counts holds the number of vertices with each configuration.
+	Counts map[string]int
+	// FaceDegrees holds the number of faces with each number of sides.
+	FaceDegrees map[int]int
+}
+
+// CalculateVertexConfigurations returns the configuration of every vertex, read
+// from the ordered star of faces around it, with the number of vertices of each
+// configuration and of faces of each degree.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) CalculateVertexConfigurations() *VertexConfigurations {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	configs := &VertexConfigurations{
+		Vertices:    make(map[int]string, len(p.Vertices)),
+		Counts:      make(map[string]int),
+		FaceDegrees: make(map[int]int),
+	}
+
+	for _, f := range p.Faces {
+		configs.FaceDegrees[len(f.Vertices)]++
+	}
+
+	g := newDartGraph(p)
+	vertices := sortedVertices(p)
+	outgoing := make([][]int, len(vertices))
+
+	for d := range g.tail {
+		outgoing[g.tail[d]] = append(outgoing[g.tail[d]], d)
+	}
+
+	for i, v := range vertices {
+		label := formatConfiguration(canonicalConfiguration(g.vertexStar(outgoing[i])))
+		configs.Vertices[v.ID] = label
+		configs.Counts[label]++
+	}
+
+	return configs
+}
+
+// String summarises the configurations and face degrees, most common first, such as
+// "5.6.6 ×60; faces 5 ×12, 6 ×20".
+func (c *VertexConfigurations) String() string {
+	labels := make([]string, 0, len(c.Counts))
+
+	for label := range c.Counts {
+		labels = append(labels, label)
+	}
+
+	slices.SortFunc(labels, func(a, b string) int {
+		return cmp.Or(cmp.Compare(c.Counts[b], c.Counts[a]), cmp.Compare(a, b))
+	})
+
+	parts := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		parts = append(parts, fmt.Sprintf("%s ×%d", label, c.Counts[label]))
+	}
+
+	degrees := make([]int, 0, len(c.FaceDegrees))
+
+	for degree := range c.FaceDegrees {
+		degrees = append(degrees, degree)
+	}
+
+	slices.Sort(degrees)
+
+	faces := make([]string, 0, len(degrees))
+
+	for _, degree := range degrees {
+		faces = append(faces, fmt.Sprintf("%d ×%d", degree, c.FaceDegrees[degree]))
+	}
+
+	return strings.Join(parts, ", ") + "; faces " + strings.Join(faces, ", ")
+}
+
+// vertexStar returns the degrees of the faces around a vertex, given its outgoing
+// darts, stepping from each dart to the one sharing the face on its right. The walk
+// starts at an open boundary if there is one, so the star is read in one piece.
+func (g *dartGraph) vertexStar(outgoing []int) []int {
+	if len(outgoing) == 0 {
+		return nil
+	}
+
+	start := outgoing[0]
+
+	for _, d := range outgoing {
+		if g.face[d] < 0 {
+			start = d
+
+			break
+		}
+	}
+
+	star := make([]int, 0, len(outgoing))
+
+	for d := start; d != noDart && len(star) < len(outgoing); {
+		star = append(star, g.faceDegree(d))
+
+		d = g.next[g.twin[d]]
+		if d == start {
+			break
+		}
+	}
+
+	return star
+}
+
+// canonicalConfiguration returns the rotation or reflection of a cyclic sequence
+// that is lexicographically smallest.
+func canonicalConfiguration(star []int) []int {
+	best := slices.Clone(star)
+	reversed := slices.Clone(star)
+	slices.Reverse(reversed)
+
+	for _, sequence := range [][]int{star, reversed} {
+		for i := range sequence {
+			rotated := append(slices.Clone(sequence[i:]), sequence[:i]...)
+
+			if slices.Compare(rotated, best) < 0 {
+				best = rotated
+			}
+		}
+	}
+
+	return best
+}
+
+// formatConfiguration joins face degrees with dots.
+func formatConfiguration(degrees []int) string {
+	parts := make([]string, len(degrees))
+
+	for i, degree := range degrees {
+		parts[i] = strconv.Itoa(degree)
+	}
+
+	return strings.Join(parts, ".")
+}
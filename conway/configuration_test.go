@@ -0,0 +1,69 @@
+package conway_test
+
+import (
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateVertexConfigurations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		notation string
+		counts   map[string]int
+		faces    map[int]int
+	}{
+		{"C", map[string]int{"4.4.4": 8}, map[int]int{4: 6}},
+		{"tI", map[string]int{"5.6.6": 60}, map[int]int{5: 12, 6: 20}},
+		{"aC", map[string]int{"3.4.3.4": 12}, map[int]int{3: 8, 4: 6}},
+		{"eC", map[string]int{"3.4.4.4": 24}, map[int]int{3: 8, 4: 18}},
+		{"kC", map[string]int{"3.3.3.3": 6, "3.3.3.3.3.3": 8}, map[int]int{3: 24}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(tt.notation)
+			configs := p.CalculateVertexConfigurations()
+
+			assert.Equal(t, tt.counts, configs.Counts)
+			assert.Equal(t, tt.faces, configs.FaceDegrees)
+			assert.Len(t, configs.Vertices, len(p.Vertices))
+		})
+	}
+}
+
+func TestVertexConfigurationOrder(t *testing.T) {
+	t.Parallel()
+
+	// Triangles and squares alternate around the cuboctahedron's vertices, which a
+	// sorted list of degrees would not distinguish from 3.3.4.4.
+	p := conway.MustParse("aC")
+	configs := p.CalculateVertexConfigurations()
+
+	for _, label := range configs.Vertices {
+		assert.Equal(t, "3.4.3.4", label, "faces alternate around every vertex, not 3.3.4.4")
+	}
+
+	assert.Equal(t, "3.4.3.4 ×12; faces 3 ×8, 4 ×6", configs.String())
+}
+
+func TestVertexConfigurationBoundary(t *testing.T) {
+	t.Parallel()
+
+	// A lone square leaves an open boundary on either side of each corner.
+	p := conway.NewPolyhedron("square")
+	p.AddFace([]*conway.Vertex{
+		p.AddVertex(conway.Vector3{X: 0, Y: 0, Z: 0}),
+		p.AddVertex(conway.Vector3{X: 1, Y: 0, Z: 0}),
+		p.AddVertex(conway.Vector3{X: 1, Y: 1, Z: 0}),
+		p.AddVertex(conway.Vector3{X: 0, Y: 1, Z: 0}),
+	})
+
+	configs := p.CalculateVertexConfigurations()
+
+	assert.Equal(t, map[string]int{"0.4": 4}, configs.Counts)
+}
@@ -0,0 +1,409 @@
+package conway
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// diskCacheMagic identifies a disk cache entry.
+	diskCacheMagic = "CNWC"
+	// diskCacheExt is the file extension of disk cache entries.
+	diskCacheExt = ".poly"
+	// diskCacheDirPerm is the permission used when creating the cache directory.
+	diskCacheDirPerm = 0o750
+	// modulePath is this library's module path, used to find its version in the build info.
+	modulePath = "github.com/sksmith/conway"
+	// diskCacheSchemaVersion is part of every key. Bump it whenever operations change
+	// the polyhedra they generate, so entries written by earlier code are not reused
+	// by builds whose version does not change, such as local or replaced builds.
+	diskCacheSchemaVersion = 1
+)
+
+// Static errors for err113 compliance.
+var (
+	ErrInvalidCacheSize = errors.New("disk cache size must be positive")
+	ErrCorruptCacheFile = errors.New("corrupt disk cache entry")
+	ErrInvalidCacheKey  = errors.New("invalid disk cache key")
+)
+
+// DiskCacheStats reports activity of a DiskCache since it was opened.
+type DiskCacheStats struct {
+	Hits        uint64 // Entries loaded from disk
+	Misses      uint64 // Lookups with no usable entry
+	Corrupt     uint64 // Entries discarded because their checksum or encoding was invalid
+	WriteErrors uint64 // Entries that could not be written
+	Evictions   uint64 // Entries removed to stay within the size limit
+}
+
+// DiskCache is a content-addressed, size-bounded cache of polyhedra on disk.
+//
+// Entries are keyed by a SHA-256 of the canonical notation, the library version and
+// VCS revision of the build, the cache schema and encoding versions and any options
+// that change the generated geometry, so an upgrade or a different option does not
+// return a stale result. Builds from a modified working tree are only told apart by
+// the schema version, which changes with the output of the operations. Each entry stores a
+// checksum of its compact binary encoding and is discarded if it fails to verify.
+// When the total size exceeds the limit, the least recently used entries are removed.
+//
+// Writes go through a temporary file and an atomic rename, so several processes can
+// share a cache directory. Thread-safe for concurrent access.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+	version  string
+
+	mu    sync.Mutex
+	stats DiskCacheStats
+}
+
+// NewDiskCache opens a disk cache in dir, creating the directory if needed.
+// The cache keeps the total size of its entries at or below maxBytes.
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidCacheSize, maxBytes)
+	}
+
+	if err := os.MkdirAll(dir, diskCacheDirPerm); err != nil {
+		return nil, fmt.Errorf("creating disk cache directory: %w", err)
+	}
+
+	return &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		version:  libraryVersion(),
+		mu:       sync.Mutex{},
+		stats:    DiskCacheStats{},
+	}, nil
+}
+
+// libraryVersion returns the version of this module recorded in the build info,
+// followed by the VCS revision of the build and whether the tree was modified. Builds
+// inside this repository report "(devel)" and path replacements no version at all,
+// so the revision is what tells them apart.
+func libraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	version := moduleVersion(info)
+
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+			version += " " + setting.Key + "=" + setting.Value
+		}
+	}
+
+	return version
+}
+
+// moduleVersion returns the version of this module in the build info, whether it is
+// the main module or a dependency.
+func moduleVersion(info *debug.BuildInfo) string {
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+
+		if dep.Replace != nil {
+			return dep.Replace.Path + "@" + dep.Replace.Version
+		}
+
+		return dep.Version
+	}
+
+	return "unknown"
+}
+
+// Key returns the cache key for notation evaluated with the given options.
+func (c *DiskCache) Key(notation string, opts ...Option) (string, error) {
+	parser := NewParser(opts...)
+
+	seedSymbol, operations, err := parser.parse(notation)
+	if err != nil {
+		return "", err
+	}
+
+	return c.key(canonicalNotation(seedSymbol, operations), parser.config), nil
+}
+
+// key derives the content address for a canonical notation and parser configuration.
+func (c *DiskCache) key(canonical string, cfg parserConfig) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		diskCacheMagic,
+		c.version,
+		fmt.Sprint(diskCacheSchemaVersion),
+		fmt.Sprint(binaryFormatVersion),
+		canonical,
+		cfg.fingerprint(),
+	}, "\x00")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// path returns the file path for a key, or false if the key is not a valid content address.
+func (c *DiskCache) path(key string) (string, bool) {
+	if len(key) != 2*sha256.Size {
+		return "", false
+	}
+
+	if _, err := hex.DecodeString(key); err != nil {
+		return "", false
+	}
+
+	return filepath.Join(c.dir, key+diskCacheExt), true
+}
+
+// Get loads the polyhedron stored under key. Entries that fail their integrity
+// check are removed and reported as a miss.
+func (c *DiskCache) Get(key string) (*Polyhedron, bool) {
+	path, ok := c.path(key)
+	if !ok {
+		c.count(func(s *DiskCacheStats) { s.Misses++ })
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		c.count(func(s *DiskCacheStats) { s.Misses++ })
+		return nil, false
+	}
+
+	p, err := decodeCacheEntry(data)
+	if err != nil {
+		_ = os.Remove(path) // Best effort; a failed removal is retried on the next lookup
+
+		c.count(func(s *DiskCacheStats) {
+			s.Corrupt++
+			s.Misses++
+		})
+
+		return nil, false
+	}
+
+	// Refresh the modification time so eviction treats the entry as recently used.
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	c.count(func(s *DiskCacheStats) { s.Hits++ })
+
+	return p, true
+}
+
+// Put stores a polyhedron under key and evicts old entries if the cache is over its size limit.
+func (c *DiskCache) Put(key string, p *Polyhedron) error {
+	err := c.put(key, p)
+	if err != nil {
+		c.count(func(s *DiskCacheStats) { s.WriteErrors++ })
+	}
+
+	return err
+}
+
+// put writes the entry through a temporary file so readers never see a partial entry.
+func (c *DiskCache) put(key string, p *Polyhedron) error {
+	path, ok := c.path(key)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidCacheKey, key)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating disk cache entry: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }() // No-op once the rename succeeded
+
+	if _, err := tmp.Write(encodeCacheEntry(p)); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing disk cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing disk cache entry: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("storing disk cache entry: %w", err)
+	}
+
+	return c.evictLocked()
+}
+
+// encodeCacheEntry frames a polyhedron's binary encoding with a magic number and checksum.
+func encodeCacheEntry(p *Polyhedron) []byte {
+	payload := p.encodeBinary()
+	sum := sha256.Sum256(payload)
+
+	entry := make([]byte, 0, len(diskCacheMagic)+len(sum)+len(payload))
+	entry = append(entry, diskCacheMagic...)
+	entry = append(entry, sum[:]...)
+
+	return append(entry, payload...)
+}
+
+// decodeCacheEntry verifies a cache entry's checksum and decodes its polyhedron.
+func decodeCacheEntry(data []byte) (*Polyhedron, error) {
+	headerSize := len(diskCacheMagic) + sha256.Size
+
+	if len(data) < headerSize || string(data[:len(diskCacheMagic)]) != diskCacheMagic {
+		return nil, fmt.Errorf("%w: missing header", ErrCorruptCacheFile)
+	}
+
+	payload := data[headerSize:]
+	sum := sha256.Sum256(payload)
+
+	if !bytes.Equal(sum[:], data[len(diskCacheMagic):headerSize]) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCorruptCacheFile)
+	}
+
+	return decodeBinary(payload)
+}
+
+// cacheFile describes an entry on disk for eviction.
+type cacheFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// listEntries returns all cache entries in the directory.
+func (c *DiskCache) listEntries() ([]cacheFile, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading disk cache directory: %w", err)
+	}
+
+	files := make([]cacheFile, 0, len(dirEntries))
+
+	for _, entry := range dirEntries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != diskCacheExt {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed by another process since ReadDir
+		}
+
+		files = append(files, cacheFile{
+			path:    filepath.Join(c.dir, entry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+
+	return files, nil
+}
+
+// evictLocked removes least recently used entries until the cache fits its size limit.
+// The caller must hold c.mu.
+func (c *DiskCache) evictLocked() error {
+	files, err := c.listEntries()
+	if err != nil {
+		return err
+	}
+
+	total := int64(0)
+
+	for _, f := range files {
+		total += f.size
+	}
+
+	if total <= c.maxBytes {
+		return nil
+	}
+
+	slices.SortFunc(files, func(a, b cacheFile) int { return a.modTime.Compare(b.modTime) })
+
+	for _, f := range files {
+		if total <= c.maxBytes {
+			break
+		}
+
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("evicting disk cache entry: %w", err)
+		}
+
+		total -= f.size
+		c.stats.Evictions++
+	}
+
+	return nil
+}
+
+// Size returns the total size in bytes of all entries currently in the cache.
+func (c *DiskCache) Size() (int64, error) {
+	files, err := c.listEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	total := int64(0)
+
+	for _, f := range files {
+		total += f.size
+	}
+
+	return total, nil
+}
+
+// Len returns the number of entries currently in the cache.
+func (c *DiskCache) Len() (int, error) {
+	files, err := c.listEntries()
+
+	return len(files), err
+}
+
+// Clear removes every entry from the cache.
+func (c *DiskCache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files, err := c.listEntries()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("clearing disk cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (c *DiskCache) Stats() DiskCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// count applies an update to the statistics under the lock.
+func (c *DiskCache) count(update func(*DiskCacheStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	update(&c.stats)
+}
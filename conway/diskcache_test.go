@@ -0,0 +1,237 @@
+package conway_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cacheEntryPath returns the file backing a cache key.
+func cacheEntryPath(dir, key string) string {
+	return filepath.Join(dir, key+".poly")
+}
+
+func TestNewDiskCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := conway.NewDiskCache(t.TempDir(), 0)
+	require.ErrorIs(t, err, conway.ErrInvalidCacheSize)
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	_, err = conway.NewDiskCache(filepath.Join(file, "cache"), 1024)
+	require.Error(t, err)
+}
+
+func TestDiskCacheRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	original := conway.MustParse("tkC")
+
+	key, err := cache.Key("tkC")
+	require.NoError(t, err)
+	require.NoError(t, cache.Put(key, original))
+
+	loaded, ok := cache.Get(key)
+	require.True(t, ok)
+
+	assert.Equal(t, original.Name, loaded.Name)
+	assert.Equal(t, original.Stats(), loaded.Stats())
+
+	for id, v := range original.Vertices {
+		require.Contains(t, loaded.Vertices, id)
+		assert.Equal(t, v.Position, loaded.Vertices[id].Position)
+	}
+
+	for id, f := range original.Faces {
+		require.Contains(t, loaded.Faces, id)
+
+		for i, v := range f.Vertices {
+			assert.Equal(t, v.ID, loaded.Faces[id].Vertices[i].ID)
+		}
+	}
+
+	for id, e := range original.Edges {
+		require.Contains(t, loaded.Edges, id)
+		assert.Same(t, loaded.Edges[id], loaded.FindEdge(e.V1.ID, e.V2.ID))
+	}
+
+	require.NoError(t, loaded.ValidateTopology())
+
+	assert.Equal(t, conway.DiskCacheStats{Hits: 1}, cache.Stats())
+}
+
+func TestDiskCacheKeys(t *testing.T) {
+	t.Parallel()
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	key, err := cache.Key("tkC")
+	require.NoError(t, err)
+	assert.Len(t, key, 64)
+
+	same, err := cache.Key("  tkC ", conway.WithWorkers(3))
+	require.NoError(t, err)
+	assert.Equal(t, key, same, "worker count does not change the geometry")
+
+	other, err := cache.Key("ktC")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	_, err = cache.Key("qC")
+	require.ErrorIs(t, err, conway.ErrUnknownOperation)
+
+	_, ok := cache.Get("../../etc/passwd")
+	assert.False(t, ok)
+
+	err = cache.Put("not-a-key", conway.Cube())
+	require.ErrorIs(t, err, conway.ErrInvalidCacheKey)
+	assert.Equal(t, uint64(1), cache.Stats().WriteErrors)
+}
+
+func TestDiskCacheDiscardsCorruptEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	cache, err := conway.NewDiskCache(dir, 1<<20)
+	require.NoError(t, err)
+
+	key, err := cache.Key("aD")
+	require.NoError(t, err)
+	require.NoError(t, cache.Put(key, conway.MustParse("aD")))
+
+	path := cacheEntryPath(dir, key)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	data[len(data)-5] ^= 0xFF
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	_, ok := cache.Get(key)
+	assert.False(t, ok)
+
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Corrupt)
+	assert.Equal(t, uint64(1), stats.Misses)
+}
+
+func TestDiskCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	probe, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	probeKey, err := probe.Key("C")
+	require.NoError(t, err)
+	require.NoError(t, probe.Put(probeKey, conway.Cube()))
+
+	entrySize, err := probe.Size()
+	require.NoError(t, err)
+
+	// Room for two cube entries but not three.
+	cache, err := conway.NewDiskCache(dir, 2*entrySize+entrySize/2)
+	require.NoError(t, err)
+
+	keys := make([]string, 3)
+
+	for i, notation := range []string{"C", "dO", "ddC"} {
+		keys[i], err = cache.Key(notation)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, cache.Put(keys[0], conway.Cube()))
+	require.NoError(t, cache.Put(keys[1], conway.Cube()))
+
+	// Age the first entry so it is the least recently used.
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(cacheEntryPath(dir, keys[0]), old, old))
+
+	require.NoError(t, cache.Put(keys[2], conway.Cube()))
+
+	count, err := cache.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, ok := cache.Get(keys[0])
+	assert.False(t, ok)
+
+	size, err := cache.Size()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, size, 2*entrySize+entrySize/2)
+	assert.Equal(t, uint64(1), cache.Stats().Evictions)
+
+	require.NoError(t, cache.Clear())
+
+	count, err = cache.Len()
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestParseWithDiskCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	first, err := conway.Parse("dtkI", conway.WithDiskCache(cache))
+	require.NoError(t, err)
+
+	second, err := conway.Parse("dtkI", conway.WithDiskCache(cache))
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Stats(), second.Stats())
+	assert.Equal(t, sortedPositions(first), sortedPositions(second))
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+
+	_, err = conway.Parse("dtkX", conway.WithDiskCache(cache))
+	require.Error(t, err)
+}
+
+func TestConcurrentDiskCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func(notation string) {
+			defer wg.Done()
+
+			result, err := conway.Parse(notation, conway.WithDiskCache(cache))
+			assert.NoError(t, err)
+			assert.True(t, result.IsValid())
+		}([]string{"tC", "kC", "aC", "jC"}[i%4])
+	}
+
+	wg.Wait()
+
+	count, err := cache.Len()
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.Zero(t, cache.Stats().WriteErrors)
+}
@@ -1,6 +1,15 @@
 package conway
 
-type DualOp struct{}
+import (
+	"cmp"
+	"slices"
+)
+
+// DualOp exchanges the roles of vertices and faces.
+// Workers sets how many goroutines build the output; zero uses one per available CPU.
+type DualOp struct {
+	Workers int
+}
 
 func (d DualOp) Symbol() string {
 	return "d"
@@ -11,56 +20,84 @@ func (d DualOp) Name() string {
 }
 
 func (d DualOp) Apply(p *Polyhedron) *Polyhedron {
-	dual := NewPolyhedron("d" + p.Name)
-
-	faceVertices := make(map[int]*Vertex)
-
-	for _, face := range p.Faces {
-		centroid := face.Centroid()
-
-		v := dual.AddVertex(centroid)
+	vertices := sortedVertices(p)
+	edges := sortedEdges(p)
+	faces := sortedFaces(p)
+	faceIndex := indexFaces(faces)
+
+	b := newMeshBuilder(len(faces), len(vertices))
+	b.trace(d.Symbol(), p, func(i, j int) Source {
+		return dualEdgeSource(faces[i], faces[j])
+	})
+
+	parallelFor(d.Workers, len(faces), func(start, end int) {
+		for i := start; i < end; i++ {
+			b.positions[i] = faces[i].Centroid()
+			b.vertexSources[i] = Source{Kind: FaceElement, ID: faces[i].ID}
+		}
+	})
 
-		faceVertices[face.ID] = v
-	}
+	b.extraEdges = make([][2]int, 0, len(edges))
 
-	for _, edge := range p.Edges {
+	for _, edge := range edges {
 		if len(edge.Faces) != 2 {
 			continue
 		}
 
-		faces := make([]*Face, 0, 2)
+		pair := [2]int{}
+		n := 0
 
 		for _, f := range edge.Faces {
-			faces = append(faces, f)
+			pair[n] = faceIndex[f.ID]
+			n++
 		}
 
-		v1 := faceVertices[faces[0].ID]
-
-		v2 := faceVertices[faces[1].ID]
+		if pair[0] > pair[1] {
+			pair[0], pair[1] = pair[1], pair[0] // Keep edge endpoints deterministic
+		}
 
-		dual.AddEdge(v1, v2)
+		b.extraEdges = append(b.extraEdges, pair)
 	}
 
-	for _, vertex := range p.Vertices {
-		if len(vertex.Faces) >= 3 {
-			orderedFaces := OrderFacesAroundVertex(vertex)
+	parallelFor(d.Workers, len(vertices), func(start, end int) {
+		for i := start; i < end; i++ {
+			if len(vertices[i].Faces) < 3 {
+				continue
+			}
 
-			dualVertices := make([]*Vertex, len(orderedFaces))
+			orderedFaces := OrderFacesAroundVertex(vertices[i])
 
-			for i, face := range orderedFaces {
-				dualVertices[i] = faceVertices[face.ID]
+			dualVertices := make([]int, len(orderedFaces))
+
+			for j, face := range orderedFaces {
+				dualVertices[j] = faceIndex[face.ID]
 			}
 
-			dual.AddFace(dualVertices)
+			b.faces[i] = dualVertices
+			b.faceSources[i] = Source{Kind: VertexElement, ID: vertices[i].ID}
 		}
-	}
+	})
+
+	dual := b.build("d"+p.Name, d.Workers)
 
 	dual.Normalize()
 
 	return dual
 }
 
-// convertFacesToSlice converts vertex faces map to slice.
+// dualEdgeSource returns the source of the edge joining the centroids of two
+// original faces: the edge they share, or the first face if they share none.
+func dualEdgeSource(f1, f2 *Face) Source {
+	for _, e := range f1.Edges {
+		if _, ok := e.Faces[f2.ID]; ok {
+			return Source{Kind: EdgeElement, ID: e.ID}
+		}
+	}
+
+	return Source{Kind: FaceElement, ID: f1.ID}
+}
+
+// convertFacesToSlice converts vertex faces map to a slice ordered by face ID.
 func convertFacesToSlice(v *Vertex) []*Face {
 	faces := make([]*Face, 0, len(v.Faces))
 
@@ -68,6 +105,8 @@ func convertFacesToSlice(v *Vertex) []*Face {
 		faces = append(faces, f)
 	}
 
+	slices.SortFunc(faces, func(a, b *Face) int { return cmp.Compare(a.ID, b.ID) })
+
 	return faces
 }
 
@@ -83,18 +122,23 @@ func facesShareEdge(face1, face2 *Face) bool {
 }
 
 // findNextFaceInEdges searches through vertex edges to find the next adjacent face.
+// When several faces qualify the one with the lowest ID is chosen, keeping the order deterministic.
 func findNextFaceInEdges(v *Vertex, currentFace *Face, visited map[int]bool) *Face {
+	var next *Face
+
 	for _, edge := range v.Edges {
 		for _, face := range edge.Faces {
-			if face.ID != currentFace.ID && !visited[face.ID] {
-				if facesShareEdge(currentFace, face) {
-					return face
-				}
+			if face.ID == currentFace.ID || visited[face.ID] || (next != nil && face.ID >= next.ID) {
+				continue
+			}
+
+			if facesShareEdge(currentFace, face) {
+				next = face
 			}
 		}
 	}
 
-	return nil
+	return next
 }
 
 // findNextUnvisitedFace finds any unvisited face (fallback).
@@ -0,0 +1,479 @@
+package conway
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"sync/atomic"
+)
+
+const (
+	// binaryFormatVersion is the current version of the binary polyhedron encoding.
+	// Version 2 added the provenance section; version 1 encodings are still accepted.
+	binaryFormatVersion = 2
+)
+
+// binaryMagic identifies a binary polyhedron encoding.
+const binaryMagic = "CNWY"
+
+// Static errors for err113 compliance.
+var (
+	ErrInvalidEncoding     = errors.New("invalid polyhedron encoding")
+	ErrUnsupportedEncoding = errors.New("unsupported polyhedron encoding version")
+)
+
+// vertexRecord is the serialized form of a vertex.
+type vertexRecord struct {
+	id       int
+	position Vector3
+}
+
+// edgeRecord is the serialized form of an edge.
+type edgeRecord struct {
+	id, v1, v2 int
+}
+
+// faceRecord is the serialized form of a face.
+type faceRecord struct {
+	id       int
+	vertices []int // Vertex IDs in boundary order
+}
+
+// polyhedronRecord is a flat, pointer-free snapshot of a polyhedron.
+type polyhedronRecord struct {
+	name       string
+	nextID     int64
+	vertices   []vertexRecord
+	edges      []edgeRecord
+	faces      []faceRecord
+	provenance *Provenance // Shared, never modified after the operation that made it
+}
+
+// snapshot captures the polyhedron as records ordered by ID.
+func (p *Polyhedron) snapshot() polyhedronRecord {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	rec := polyhedronRecord{
+		name:       p.Name,
+		nextID:     atomic.LoadInt64(&p.nextID),
+		vertices:   make([]vertexRecord, 0, len(p.Vertices)),
+		edges:      make([]edgeRecord, 0, len(p.Edges)),
+		faces:      make([]faceRecord, 0, len(p.Faces)),
+		provenance: p.Provenance,
+	}
+
+	for _, v := range sortedVertices(p) {
+		rec.vertices = append(rec.vertices, vertexRecord{id: v.ID, position: v.Position})
+	}
+
+	for _, e := range sortedEdges(p) {
+		rec.edges = append(rec.edges, edgeRecord{id: e.ID, v1: e.V1.ID, v2: e.V2.ID})
+	}
+
+	for _, f := range sortedFaces(p) {
+		ids := make([]int, len(f.Vertices))
+
+		for i, v := range f.Vertices {
+			ids[i] = v.ID
+		}
+
+		rec.faces = append(rec.faces, faceRecord{id: f.ID, vertices: ids})
+	}
+
+	return rec
+}
+
+// restore rebuilds a polyhedron from records, preserving every ID and the face
+// vertex order exactly. Edges, face edge lists and the edge lookup are recreated.
+func (rec polyhedronRecord) restore() (*Polyhedron, error) {
+	p := NewPolyhedron(rec.name)
+
+	p.Vertices = make(map[int]*Vertex, len(rec.vertices))
+	p.Edges = make(map[int]*Edge, len(rec.edges))
+	p.Faces = make(map[int]*Face, len(rec.faces))
+
+	// Edges created while linking faces that are missing from the records get IDs past the stored ones.
+	p.nextID = max(rec.nextID, rec.maxID())
+
+	for _, vr := range rec.vertices {
+		if _, exists := p.Vertices[vr.id]; exists {
+			return nil, fmt.Errorf("%w: duplicate vertex ID %d", ErrInvalidEncoding, vr.id)
+		}
+
+		p.Vertices[vr.id] = NewVertex(vr.id, vr.position)
+	}
+
+	for _, er := range rec.edges {
+		if err := p.restoreEdge(er); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fr := range rec.faces {
+		if err := p.restoreFace(fr); err != nil {
+			return nil, err
+		}
+	}
+
+	p.Provenance = rec.provenance
+
+	return p, nil
+}
+
+// maxID returns the largest element ID in the records.
+func (rec polyhedronRecord) maxID() int64 {
+	maxID := 0
+
+	for _, vr := range rec.vertices {
+		maxID = max(maxID, vr.id)
+	}
+
+	for _, er := range rec.edges {
+		maxID = max(maxID, er.id)
+	}
+
+	for _, fr := range rec.faces {
+		maxID = max(maxID, fr.id)
+	}
+
+	return int64(maxID)
+}
+
+// restoreEdge links an edge record into a polyhedron being restored.
+func (p *Polyhedron) restoreEdge(er edgeRecord) error {
+	v1, ok1 := p.Vertices[er.v1]
+	v2, ok2 := p.Vertices[er.v2]
+
+	if !ok1 || !ok2 {
+		return fmt.Errorf("%w: edge %d references unknown vertex", ErrInvalidEncoding, er.id)
+	}
+
+	if _, exists := p.Edges[er.id]; exists || p.edgeLookup.Find(er.v1, er.v2) != nil {
+		return fmt.Errorf("%w: duplicate edge %d", ErrInvalidEncoding, er.id)
+	}
+
+	e := NewEdge(er.id, v1, v2)
+
+	p.Edges[e.ID] = e
+	p.edgeLookup.Add(e)
+	v1.Edges[e.ID] = e
+	v2.Edges[e.ID] = e
+
+	return nil
+}
+
+// restoreFace links a face record into a polyhedron being restored.
+func (p *Polyhedron) restoreFace(fr faceRecord) error {
+	if _, exists := p.Faces[fr.id]; exists {
+		return fmt.Errorf("%w: duplicate face ID %d", ErrInvalidEncoding, fr.id)
+	}
+
+	vertices := make([]*Vertex, len(fr.vertices))
+
+	for i, id := range fr.vertices {
+		v, ok := p.Vertices[id]
+		if !ok {
+			return fmt.Errorf("%w: face %d references unknown vertex %d", ErrInvalidEncoding, fr.id, id)
+		}
+
+		vertices[i] = v
+	}
+
+	p.linkFaceUnsafe(NewFace(fr.id, vertices))
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using a compact, versioned format.
+// All element IDs and face vertex orders are preserved.
+func (p *Polyhedron) MarshalBinary() ([]byte, error) {
+	return p.encodeBinary(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of p.
+// Edges, connectivity and the edge lookup are rebuilt from the stored elements.
+func (p *Polyhedron) UnmarshalBinary(data []byte) error {
+	decoded, err := decodeBinary(data)
+	if err != nil {
+		return err
+	}
+
+	p.replaceWith(decoded)
+
+	return nil
+}
+
+// replaceWith moves the contents of a freshly built polyhedron into p.
+func (p *Polyhedron) replaceWith(other *Polyhedron) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Name = other.Name
+	p.Vertices = other.Vertices
+	p.Edges = other.Edges
+	p.Faces = other.Faces
+	p.edgeLookup = other.edgeLookup
+	p.Provenance = other.Provenance
+	p.cachedCentroid = nil
+	atomic.StoreInt64(&p.nextID, atomic.LoadInt64(&other.nextID))
+}
+
+// encodeBinary writes the compact, versioned binary encoding of the polyhedron:
+// a magic number and version, the name, vertices, edges and faces with their IDs,
+// then the provenance chain from the newest step.
+func (p *Polyhedron) encodeBinary() []byte {
+	rec := p.snapshot()
+
+	buf := make([]byte, 0, binaryEncodingSizeHint(rec))
+	buf = append(buf, binaryMagic...)
+	buf = binary.LittleEndian.AppendUint16(buf, binaryFormatVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(rec.name)))
+	buf = append(buf, rec.name...)
+	buf = binary.AppendVarint(buf, rec.nextID)
+
+	buf = binary.AppendUvarint(buf, uint64(len(rec.vertices)))
+	for _, vr := range rec.vertices {
+		buf = binary.AppendVarint(buf, int64(vr.id))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(vr.position.X))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(vr.position.Y))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(vr.position.Z))
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(rec.edges)))
+	for _, er := range rec.edges {
+		buf = binary.AppendVarint(buf, int64(er.id))
+		buf = binary.AppendVarint(buf, int64(er.v1))
+		buf = binary.AppendVarint(buf, int64(er.v2))
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(rec.faces)))
+	for _, fr := range rec.faces {
+		buf = binary.AppendVarint(buf, int64(fr.id))
+		buf = binary.AppendUvarint(buf, uint64(len(fr.vertices)))
+
+		for _, id := range fr.vertices {
+			buf = binary.AppendVarint(buf, int64(id))
+		}
+	}
+
+	return appendProvenance(buf, rec.provenance)
+}
+
+// appendProvenance encodes a provenance chain as a step count followed by each
+// step's operation and its vertex, edge and face sources in ID order.
+func appendProvenance(buf []byte, pr *Provenance) []byte {
+	buf = binary.AppendUvarint(buf, uint64(pr.Depth()))
+
+	for step := pr; step != nil; step = step.Parent {
+		buf = binary.AppendUvarint(buf, uint64(len(step.Operation)))
+		buf = append(buf, step.Operation...)
+
+		for _, kind := range elementKinds() {
+			sources := step.elements(kind)
+
+			buf = binary.AppendUvarint(buf, uint64(len(sources)))
+			for _, id := range slices.Sorted(maps.Keys(sources)) {
+				buf = binary.AppendVarint(buf, int64(id))
+				buf = append(buf, byte(sources[id].Kind))
+				buf = binary.AppendVarint(buf, int64(sources[id].ID))
+			}
+		}
+	}
+
+	return buf
+}
+
+// binaryEncodingSizeHint estimates the encoded size to avoid buffer regrowth.
+func binaryEncodingSizeHint(rec polyhedronRecord) int {
+	const (
+		headerSize = 16
+		vertexSize = 28
+		edgeSize   = 9
+		faceSize   = 4
+		indexSize  = 3
+	)
+
+	size := headerSize + len(rec.name) + vertexSize*len(rec.vertices) + edgeSize*len(rec.edges)
+
+	for _, fr := range rec.faces {
+		size += faceSize + indexSize*len(fr.vertices)
+	}
+
+	return size
+}
+
+// binaryReader decodes the primitives of the binary encoding, remembering the first error.
+type binaryReader struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (br *binaryReader) varint() int {
+	if br.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadVarint(br.r)
+	if err != nil {
+		br.err = err
+	}
+
+	return int(v)
+}
+
+func (br *binaryReader) uvarint() int {
+	if br.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadUvarint(br.r)
+	if err != nil {
+		br.err = err
+		return 0
+	}
+
+	// Counts can never exceed the remaining input; reject them before allocating.
+	if v > uint64(br.r.Len()) {
+		br.err = io.ErrUnexpectedEOF
+		return 0
+	}
+
+	return int(v)
+}
+
+func (br *binaryReader) float() float64 {
+	var bits [8]byte
+
+	if br.err != nil {
+		return 0
+	}
+
+	if _, err := io.ReadFull(br.r, bits[:]); err != nil {
+		br.err = err
+		return 0
+	}
+
+	return math.Float64frombits(binary.LittleEndian.Uint64(bits[:]))
+}
+
+func (br *binaryReader) kind() ElementKind {
+	if br.err != nil {
+		return 0
+	}
+
+	b, err := br.r.ReadByte()
+	if err != nil {
+		br.err = err
+		return 0
+	}
+
+	kind := ElementKind(b)
+	if !kind.valid() {
+		br.err = fmt.Errorf("%w: %d", ErrInvalidElementKind, b)
+	}
+
+	return kind
+}
+
+func (br *binaryReader) bytes(n int) []byte {
+	if br.err != nil {
+		return nil
+	}
+
+	b := make([]byte, n)
+
+	if _, err := io.ReadFull(br.r, b); err != nil {
+		br.err = err
+		return nil
+	}
+
+	return b
+}
+
+// provenance reads a chain written by appendProvenance.
+func (br *binaryReader) provenance() *Provenance {
+	steps := make([]*Provenance, br.uvarint())
+
+	for i := range steps {
+		steps[i] = newProvenance(string(br.bytes(br.uvarint())), nil)
+
+		for _, kind := range elementKinds() {
+			sources := steps[i].elements(kind)
+
+			for range br.uvarint() {
+				id := br.varint()
+				sources[id] = Source{Kind: br.kind(), ID: br.varint()}
+			}
+		}
+	}
+
+	for i := len(steps) - 1; i > 0; i-- {
+		steps[i-1].Parent = steps[i]
+	}
+
+	if br.err != nil || len(steps) == 0 {
+		return nil
+	}
+
+	return steps[0]
+}
+
+// decodeBinary parses the binary encoding produced by encodeBinary.
+func decodeBinary(data []byte) (*Polyhedron, error) {
+	const headerSize = len(binaryMagic) + 2
+
+	if len(data) < headerSize || string(data[:len(binaryMagic)]) != binaryMagic {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidEncoding)
+	}
+
+	version := binary.LittleEndian.Uint16(data[len(binaryMagic):])
+	if version < 1 || version > binaryFormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedEncoding, version)
+	}
+
+	br := &binaryReader{r: bytes.NewReader(data[headerSize:]), err: nil}
+	rec := polyhedronRecord{}
+
+	rec.name = string(br.bytes(br.uvarint()))
+	rec.nextID = int64(br.varint())
+
+	rec.vertices = make([]vertexRecord, br.uvarint())
+	for i := range rec.vertices {
+		rec.vertices[i] = vertexRecord{id: br.varint(), position: Vector3{X: br.float(), Y: br.float(), Z: br.float()}}
+	}
+
+	rec.edges = make([]edgeRecord, br.uvarint())
+	for i := range rec.edges {
+		rec.edges[i] = edgeRecord{id: br.varint(), v1: br.varint(), v2: br.varint()}
+	}
+
+	rec.faces = make([]faceRecord, br.uvarint())
+	for i := range rec.faces {
+		rec.faces[i].id = br.varint()
+		rec.faces[i].vertices = make([]int, br.uvarint())
+
+		for j := range rec.faces[i].vertices {
+			rec.faces[i].vertices[j] = br.varint()
+		}
+	}
+
+	if version >= 2 {
+		rec.provenance = br.provenance()
+	}
+
+	if br.err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, br.err)
+	}
+
+	if br.r.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, br.r.Len())
+	}
+
+	return rec.restore()
+}
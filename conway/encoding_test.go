@@ -0,0 +1,153 @@
+package conway_test
+
+import (
+	"encoding"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile-time checks that Polyhedron implements the standard encoding interfaces.
+var (
+	_ encoding.BinaryMarshaler   = (*conway.Polyhedron)(nil)
+	_ encoding.BinaryUnmarshaler = (*conway.Polyhedron)(nil)
+)
+
+// assertSamePolyhedron checks that two polyhedra have identical elements, IDs and face orders.
+func assertSamePolyhedron(t *testing.T, expected, actual *conway.Polyhedron) {
+	t.Helper()
+
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Stats(), actual.Stats())
+
+	for id, v := range expected.Vertices {
+		require.Contains(t, actual.Vertices, id)
+		assert.Equal(t, v.Position, actual.Vertices[id].Position)
+		assert.Equal(t, v.Degree(), actual.Vertices[id].Degree())
+	}
+
+	for id, e := range expected.Edges {
+		require.Contains(t, actual.Edges, id)
+		assert.Equal(t, e.V1.ID, actual.Edges[id].V1.ID)
+		assert.Equal(t, e.V2.ID, actual.Edges[id].V2.ID)
+		assert.Len(t, actual.Edges[id].Faces, len(e.Faces))
+	}
+
+	for id, f := range expected.Faces {
+		require.Contains(t, actual.Faces, id)
+		require.Len(t, actual.Faces[id].Vertices, len(f.Vertices))
+
+		for i, v := range f.Vertices {
+			assert.Equal(t, v.ID, actual.Faces[id].Vertices[i].ID)
+			assert.Equal(t, f.Edges[i].ID, actual.Faces[id].Edges[i].ID)
+		}
+	}
+
+	assert.Equal(t, expected.Provenance, actual.Provenance)
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "tI", "dtkC", "sD"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			original := conway.MustParse(notation)
+
+			data, err := original.MarshalBinary()
+			require.NoError(t, err)
+
+			decoded := conway.NewPolyhedron("placeholder")
+			require.NoError(t, decoded.UnmarshalBinary(data))
+
+			assertSamePolyhedron(t, original, decoded)
+			assert.True(t, decoded.IsValid())
+
+			// New elements continue the ID sequence rather than colliding.
+			v := decoded.AddVertex(conway.Vector3{})
+			assert.NotContains(t, original.Vertices, v.ID)
+			assert.NotContains(t, original.Edges, v.ID)
+			assert.NotContains(t, original.Faces, v.ID)
+		})
+	}
+}
+
+func TestBinaryCompactness(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("tI")
+
+	binaryData, err := p.MarshalBinary()
+	require.NoError(t, err)
+
+	jsonData, err := p.MarshalJSON()
+	require.NoError(t, err)
+
+	assert.Less(t, len(binaryData), len(jsonData)/2)
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	t.Parallel()
+
+	valid, err := conway.Cube().MarshalBinary()
+	require.NoError(t, err)
+
+	t.Run("MissingHeader", func(t *testing.T) {
+		t.Parallel()
+
+		err := conway.NewPolyhedron("x").UnmarshalBinary([]byte("nope"))
+		require.ErrorIs(t, err, conway.ErrInvalidEncoding)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		t.Parallel()
+
+		data := append([]byte(nil), valid...)
+		data[4] = 99
+
+		err := conway.NewPolyhedron("x").UnmarshalBinary(data)
+		require.ErrorIs(t, err, conway.ErrUnsupportedEncoding)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		t.Parallel()
+
+		for _, n := range []int{7, len(valid) / 2, len(valid) - 1} {
+			err := conway.NewPolyhedron("x").UnmarshalBinary(valid[:n])
+			require.ErrorIs(t, err, conway.ErrInvalidEncoding, "length %d", n)
+		}
+	})
+
+	t.Run("Version1", func(t *testing.T) {
+		t.Parallel()
+
+		// Version 1 has no provenance section; a seed's section is a single zero step count.
+		data := append([]byte(nil), valid[:len(valid)-1]...)
+		data[4] = 1
+
+		decoded := conway.NewPolyhedron("x")
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		assertSamePolyhedron(t, conway.Cube(), decoded)
+	})
+
+	t.Run("TrailingBytes", func(t *testing.T) {
+		t.Parallel()
+
+		data := append(append([]byte(nil), valid...), 0)
+
+		err := conway.NewPolyhedron("x").UnmarshalBinary(data)
+		require.ErrorIs(t, err, conway.ErrInvalidEncoding)
+	})
+
+	t.Run("FailedDecodeLeavesTargetUntouched", func(t *testing.T) {
+		t.Parallel()
+
+		target := conway.Tetrahedron()
+
+		require.Error(t, target.UnmarshalBinary(valid[:10]))
+		assert.Equal(t, "Tetrahedron: V=4, E=6, F=4, χ=2", target.Stats())
+	})
+}
@@ -0,0 +1,220 @@
+package conway
+
+import (
+	"container/list"
+	"sync"
+)
+
+const (
+	// defaultEvaluatorCapacity is the number of cached polyhedra used when no capacity is given.
+	defaultEvaluatorCapacity = 256
+)
+
+// EvaluatorStats reports cache activity for an Evaluator.
+type EvaluatorStats struct {
+	Hits      uint64 // Sub-notations served from the cache
+	Misses    uint64 // Sub-notations that had to be computed
+	Evictions uint64 // Entries dropped to stay within capacity
+	Entries   int    // Entries currently cached
+	Capacity  int    // Maximum number of cached entries
+}
+
+// evaluatorEntry is a cached polyhedron stored in the LRU list.
+type evaluatorEntry struct {
+	key        string
+	polyhedron *Polyhedron
+}
+
+// Evaluator evaluates Conway notation with a bounded LRU cache of intermediate results.
+// Results are keyed by canonical sub-notation, so notations sharing a suffix reuse
+// the work: after evaluating "tkI", evaluating "dtkI" only applies the dual.
+//
+// Cached polyhedra are never handed out directly; Evaluate returns a clone that the
+// caller may modify freely. Thread-safe for concurrent access.
+type Evaluator struct {
+	parser   *Parser
+	capacity int
+
+	mu        sync.Mutex
+	entries   map[string]*list.Element
+	order     *list.List // Most recently used entries at the front
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// NewEvaluator creates an evaluator caching up to capacity polyhedra.
+// A capacity of zero or less selects a default of 256. The options configure
+// the operations exactly as they would for Parse, and a disk cache given with
+// WithDiskCache is shared with Parse: finished polyhedra missing from memory are
+// loaded from it, and computed ones are written to it.
+func NewEvaluator(capacity int, opts ...Option) *Evaluator {
+	if capacity <= 0 {
+		capacity = defaultEvaluatorCapacity
+	}
+
+	return &Evaluator{
+		parser:    NewParser(opts...),
+		capacity:  capacity,
+		mu:        sync.Mutex{},
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+		hits:      0,
+		misses:    0,
+		evictions: 0,
+	}
+}
+
+// Evaluate builds the polyhedron described by notation, reusing cached results for
+// the longest already-computed suffix and caching every newly computed sub-notation.
+// If the notation is not cached in memory, the disk cache, if any, is tried first.
+func (e *Evaluator) Evaluate(notation string) (*Polyhedron, error) {
+	seedSymbol, operations, err := e.parser.parse(notation)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sub-notation i is operations[i:] applied to the seed; i == len(operations) is the seed itself.
+	keys := make([]string, len(operations)+1)
+
+	for i := range keys {
+		keys[i] = canonicalNotation(seedSymbol, operations[i:])
+	}
+
+	start, result := e.longestCachedSuffix(keys)
+
+	// Only finished polyhedra go to disk, so the disk cache helps when the whole
+	// notation is not in memory.
+	cache := e.parser.config.diskCache
+	diskKey := ""
+
+	if cache != nil && start > 0 {
+		diskKey = cache.key(keys[0], e.parser.config)
+
+		if cached, ok := cache.Get(diskKey); ok {
+			return cached, nil
+		}
+	}
+
+	if result == nil {
+		result = GetSeed(seedSymbol)
+		e.store(keys[start], result)
+	}
+
+	for i := start - 1; i >= 0; i-- {
+		result = operations[i].Apply(result)
+		e.store(keys[i], result)
+	}
+
+	if diskKey != "" {
+		e.storeOnDisk(cache, diskKey, result)
+	}
+
+	result = result.Clone()
+	e.parser.place(result)
+
+	return result, nil
+}
+
+// storeOnDisk writes a computed polyhedron to the disk cache placed as Parse places
+// it. Unlike Clone, the copy keeps every element ID, so the entry is what Parse would
+// have stored itself.
+func (e *Evaluator) storeOnDisk(cache *DiskCache, key string, p *Polyhedron) {
+	stored, err := p.snapshot().restore()
+	if err != nil {
+		return
+	}
+
+	e.parser.place(stored)
+
+	// A failed write only costs a later recomputation; it is reported in the cache stats.
+	_ = cache.Put(key, stored)
+}
+
+// longestCachedSuffix returns the index of the longest cached sub-notation and its polyhedron.
+// If nothing is cached the seed index is returned with a nil polyhedron.
+func (e *Evaluator) longestCachedSuffix(keys []string) (int, *Polyhedron) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i, key := range keys {
+		if elem, ok := e.entries[key]; ok {
+			e.order.MoveToFront(elem)
+			e.hits++
+			e.misses += uint64(i)
+
+			entry, _ := elem.Value.(*evaluatorEntry)
+
+			return i, entry.polyhedron
+		}
+	}
+
+	e.misses += uint64(len(keys))
+
+	return len(keys) - 1, nil
+}
+
+// store caches a polyhedron under key, evicting the least recently used entries if needed.
+func (e *Evaluator) store(key string, p *Polyhedron) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if elem, ok := e.entries[key]; ok {
+		// Another caller computed the same sub-notation concurrently.
+		e.order.MoveToFront(elem)
+		return
+	}
+
+	e.entries[key] = e.order.PushFront(&evaluatorEntry{key: key, polyhedron: p})
+
+	for e.order.Len() > e.capacity {
+		oldest := e.order.Back()
+
+		entry, _ := e.order.Remove(oldest).(*evaluatorEntry)
+
+		delete(e.entries, entry.key)
+		e.evictions++
+	}
+}
+
+// Contains reports whether the canonical form of notation is currently cached.
+// It does not update recency or statistics.
+func (e *Evaluator) Contains(notation string) bool {
+	seedSymbol, operations, err := e.parser.parse(notation)
+	if err != nil {
+		return false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, ok := e.entries[canonicalNotation(seedSymbol, operations)]
+
+	return ok
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (e *Evaluator) Stats() EvaluatorStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return EvaluatorStats{
+		Hits:      e.hits,
+		Misses:    e.misses,
+		Evictions: e.evictions,
+		Entries:   e.order.Len(),
+		Capacity:  e.capacity,
+	}
+}
+
+// Reset empties the cache and clears the statistics.
+func (e *Evaluator) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.entries = make(map[string]*list.Element)
+	e.order.Init()
+	e.hits = 0
+	e.misses = 0
+	e.evictions = 0
+}
@@ -0,0 +1,207 @@
+package conway_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluatorMatchesParse(t *testing.T) {
+	t.Parallel()
+
+	evaluator := conway.NewEvaluator(0)
+
+	for _, notation := range []string{"I", "kI", "tkI", "dtkI", "atkI", "sC", "jD"} {
+		expected := conway.MustParse(notation)
+
+		result, err := evaluator.Evaluate(notation)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected.Stats(), result.Stats(), notation)
+		assert.Equal(t, sortedPositions(expected), sortedPositions(result), notation)
+	}
+}
+
+func TestEvaluatorSharesSuffixes(t *testing.T) {
+	t.Parallel()
+
+	evaluator := conway.NewEvaluator(16)
+
+	_, err := evaluator.Evaluate("tkI")
+	require.NoError(t, err)
+
+	stats := evaluator.Stats()
+	assert.Equal(t, uint64(0), stats.Hits)
+	assert.Equal(t, uint64(3), stats.Misses) // I, kI, tkI
+	assert.Equal(t, 3, stats.Entries)
+	assert.True(t, evaluator.Contains("kI"))
+
+	_, err = evaluator.Evaluate("dtkI")
+	require.NoError(t, err)
+
+	_, err = evaluator.Evaluate("atkI")
+	require.NoError(t, err)
+
+	stats = evaluator.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(5), stats.Misses) // Only dtkI and atkI were added
+	assert.Equal(t, 5, stats.Entries)
+
+	_, err = evaluator.Evaluate(" dtkI ")
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(3), evaluator.Stats().Hits)
+	assert.Equal(t, uint64(5), evaluator.Stats().Misses)
+}
+
+func TestEvaluatorEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	evaluator := conway.NewEvaluator(2)
+
+	_, err := evaluator.Evaluate("dC") // Caches C, dC
+	require.NoError(t, err)
+
+	_, err = evaluator.Evaluate("T") // Evicts C
+	require.NoError(t, err)
+
+	stats := evaluator.Stats()
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, 2, stats.Capacity)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.False(t, evaluator.Contains("C"))
+	assert.True(t, evaluator.Contains("dC"))
+	assert.True(t, evaluator.Contains("T"))
+}
+
+func TestEvaluatorReturnsIndependentCopies(t *testing.T) {
+	t.Parallel()
+
+	evaluator := conway.NewEvaluator(4)
+
+	first, err := evaluator.Evaluate("aC")
+	require.NoError(t, err)
+
+	for _, v := range first.Vertices {
+		v.Position = conway.Vector3{X: 10, Y: 10, Z: 10}
+	}
+
+	second, err := evaluator.Evaluate("aC")
+	require.NoError(t, err)
+
+	assert.Equal(t, sortedPositions(conway.MustParse("aC")), sortedPositions(second))
+}
+
+func TestEvaluatorErrors(t *testing.T) {
+	t.Parallel()
+
+	evaluator := conway.NewEvaluator(4)
+
+	_, err := evaluator.Evaluate("")
+	require.ErrorIs(t, err, conway.ErrEmptyNotation)
+
+	_, err = evaluator.Evaluate("xC")
+	require.ErrorIs(t, err, conway.ErrUnknownOperation)
+
+	_, err = evaluator.Evaluate("dd")
+	require.ErrorIs(t, err, conway.ErrNoSeedPolyhedron)
+
+	assert.Equal(t, conway.EvaluatorStats{Capacity: 4}, evaluator.Stats())
+	assert.False(t, evaluator.Contains("x"))
+}
+
+func TestEvaluatorReset(t *testing.T) {
+	t.Parallel()
+
+	evaluator := conway.NewEvaluator(4)
+
+	_, err := evaluator.Evaluate("kT")
+	require.NoError(t, err)
+
+	evaluator.Reset()
+
+	assert.Equal(t, conway.EvaluatorStats{Capacity: 4}, evaluator.Stats())
+	assert.False(t, evaluator.Contains("kT"))
+}
+
+func TestEvaluatorWithDiskCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	first, err := conway.NewEvaluator(0, conway.WithDiskCache(cache)).Evaluate("dtkI")
+	require.NoError(t, err)
+
+	// A fresh evaluator has nothing in memory and loads the result from disk.
+	second, err := conway.NewEvaluator(0, conway.WithDiskCache(cache)).Evaluate("dtkI")
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Stats(), second.Stats())
+	assert.Equal(t, sortedPositions(first), sortedPositions(second))
+
+	// Parse shares the entries.
+	_, err = conway.Parse("dtkI", conway.WithDiskCache(cache))
+	require.NoError(t, err)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+}
+
+func TestEvaluatorDiskCacheMatchesParse(t *testing.T) {
+	t.Parallel()
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	// "dC" is computed from the cube already in memory and written to disk.
+	evaluator := conway.NewEvaluator(0, conway.WithDiskCache(cache))
+	_, err = evaluator.Evaluate("C")
+	require.NoError(t, err)
+	_, err = evaluator.Evaluate("dC")
+	require.NoError(t, err)
+
+	cached, err := conway.Parse("dC", conway.WithDiskCache(cache))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), cache.Stats().Hits)
+
+	fromCache, err := json.Marshal(cached)
+	require.NoError(t, err)
+
+	fresh, err := json.Marshal(conway.MustParse("dC"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(fresh), string(fromCache), "cached entries keep Parse's element IDs")
+}
+
+func TestConcurrentEvaluator(t *testing.T) {
+	t.Parallel()
+
+	evaluator := conway.NewEvaluator(8, conway.WithWorkers(2))
+	notations := []string{"tkI", "dtkI", "atkI", "kI", "jC", "ajC"}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 24; i++ {
+		wg.Add(1)
+
+		go func(notation string) {
+			defer wg.Done()
+
+			result, err := evaluator.Evaluate(notation)
+			assert.NoError(t, err)
+			assert.True(t, result.IsValid(), notation)
+		}(notations[i%len(notations)])
+	}
+
+	wg.Wait()
+
+	stats := evaluator.Stats()
+	assert.LessOrEqual(t, stats.Entries, 8)
+	assert.Positive(t, stats.Hits)
+}
@@ -0,0 +1,449 @@
+package conway
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultLengthTolerance is the default largest difference between strut lengths
+	// given the same label.
+	defaultLengthTolerance = 1e-6
+	// cutTolerance is how far below a cut plane a vertex may lie and still be kept, in
+	// the polyhedron's own units.
+	cutTolerance = 1e-9
+	// fabricationPrecision is the number of decimals written for lengths and angles.
+	fabricationPrecision = 4
+	// labelLetters is the number of letters used for strut labels before they gain a
+	// second letter.
+	labelLetters = 26
+	// radiansToDegrees converts angles for fabrication output.
+	radiansToDegrees = 180 / math.Pi
+)
+
+// ErrEmptyDome is returned when a cut plane leaves no struts.
+var ErrEmptyDome = errors.New("cut plane removes every strut")
+
+// FabricationOption configures a fabrication report.
+type FabricationOption func(*fabricationConfig)
+
+// fabricationConfig holds fabrication report settings.
+type fabricationConfig struct {
+	tolerance float64
+	radius    float64 // Zero keeps the polyhedron's own scale
+	cut       bool
+	cutPoint  Vector3
+	cutNormal Vector3
+}
+
+func newFabricationConfig(opts []FabricationOption) fabricationConfig {
+	cfg := fabricationConfig{
+		tolerance: defaultLengthTolerance,
+		radius:    0,
+		cut:       false,
+		cutPoint:  Vector3{X: 0, Y: 0, Z: 0},
+		cutNormal: Vector3{X: 0, Y: 0, Z: 0},
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithLengthTolerance sets the largest difference between strut lengths, in report
+// units, for them to share a label. The default is 1e-6.
+func WithLengthTolerance(tolerance float64) FabricationOption {
+	return func(cfg *fabricationConfig) {
+		cfg.tolerance = tolerance
+	}
+}
+
+// WithDomeRadius scales the report so the furthest vertex is radius units from the
+// centroid, giving strut lengths in building units. By default lengths are in the
+// polyhedron's own units.
+func WithDomeRadius(radius float64) FabricationOption {
+	return func(cfg *fabricationConfig) {
+		cfg.radius = radius
+	}
+}
+
+// WithCutPlane truncates the polyhedron at a plane, in its own coordinates, to form
+// a flat-based dome. Vertices on the side the normal points to, or on the plane, are
+// kept along with the struts joining them; hubs that lose struts to the cut are
+// marked as base hubs.
+func WithCutPlane(point, normal Vector3) FabricationOption {
+	return func(cfg *fabricationConfig) {
+		cfg.cut, cfg.cutPoint, cfg.cutNormal = true, point, normal.Normalize()
+	}
+}
+
+// StrutGroup is a set of struts of the same length within tolerance.
+type StrutGroup struct {
+	Label  string  // A for the shortest struts, then B, C, and so on
+	Length float64 // Mean length of the group's struts
+	Count  int     // Number of struts in the group
+}
+
+// Strut is an edge of the dome.
+type Strut struct {
+	Edge   int     // Edge ID in the polyhedron
+	Label  string  // Label of the strut's length group
+	Length float64 // Length in report units
+	Hubs   [2]int  // Vertex IDs of the strut's ends
+}
+
+// HubStrut is a strut meeting a hub, with the angles for cutting its end.
+type HubStrut struct {
+	Edge  int    // Edge ID of the strut
+	Label string // Label of the strut's length group
+	// AxialAngle is the angle in degrees by which the strut dips below the plane
+	// perpendicular to the hub's axis, the line from the centroid through the hub.
+	AxialAngle float64
+	// DihedralAngle is the angle in degrees between the planes through the hub's axis
+	// containing this strut and the next, turning counter-clockwise seen from outside.
+	DihedralAngle float64
+}
+
+// Hub is a vertex of the dome where struts meet.
+type Hub struct {
+	Vertex   int        // Vertex ID in the polyhedron
+	Position Vector3    // Position in report units, relative to the centroid
+	Base     bool       // The hub lost struts to the cut plane
+	Struts   []HubStrut // Struts counter-clockwise seen from outside
+}
+
+// FabricationReport lists the parts needed to build a polyhedron, typically a
+// geodesic dome, as a frame of struts joined at hubs.
+type FabricationReport struct {
+	Name   string       // Name of the polyhedron
+	Radius float64      // Distance from the centroid to the furthest vertex, in report units
+	Groups []StrutGroup // Strut length groups, shortest first
+	Struts []Strut      // Struts in edge ID order
+	Hubs   []Hub        // Hubs in vertex ID order
+}
+
+// Fabricate analyses the polyhedron as a frame of struts: edges are grouped by
+// length and labelled, and every hub records the axial and dihedral angles of the
+// struts meeting it. Options set the length tolerance, the scale and a cut plane
+// forming a flat-based dome.
+func Fabricate(p *Polyhedron, opts ...FabricationOption) (*FabricationReport, error) {
+	cfg := newFabricationConfig(opts)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	center := p.calculateCentroidUnsafe()
+	radius := 0.0
+
+	for _, v := range p.Vertices {
+		radius = math.Max(radius, v.Position.Distance(center))
+	}
+
+	scale := 1.0
+	if cfg.radius > 0 && radius > 0 {
+		scale = cfg.radius / radius
+	}
+
+	kept := func(v *Vertex) bool {
+		return !cfg.cut || v.Position.Sub(cfg.cutPoint).Dot(cfg.cutNormal) >= -cutTolerance
+	}
+
+	report := &FabricationReport{Name: p.Name, Radius: radius * scale, Groups: nil, Struts: nil, Hubs: nil}
+
+	for _, e := range sortedEdges(p) {
+		if kept(e.V1) && kept(e.V2) {
+			report.Struts = append(report.Struts, Strut{Edge: e.ID, Label: "", Length: e.Length() * scale, Hubs: [2]int{e.V1.ID, e.V2.ID}})
+		}
+	}
+
+	if len(report.Struts) == 0 {
+		return nil, fmt.Errorf("fabricating %s: %w", p.Name, ErrEmptyDome)
+	}
+
+	report.labelStruts(cfg.tolerance)
+
+	labels := make(map[int]string, len(report.Struts))
+
+	for _, s := range report.Struts {
+		labels[s.Edge] = s.Label
+	}
+
+	for _, v := range sortedVertices(p) {
+		if hub := newHub(v, center, scale, labels); hub != nil {
+			report.Hubs = append(report.Hubs, *hub)
+		}
+	}
+
+	return report, nil
+}
+
+// labelStruts groups the struts by length, starting a new group whenever a strut is
+// longer than the group's shortest by more than the tolerance.
+func (r *FabricationReport) labelStruts(tolerance float64) {
+	order := make([]int, len(r.Struts))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(r.Struts[a].Length, r.Struts[b].Length) })
+
+	var shortest, total float64
+
+	for _, i := range order {
+		s := &r.Struts[i]
+
+		if len(r.Groups) == 0 || s.Length-shortest > tolerance {
+			r.Groups = append(r.Groups, StrutGroup{Label: strutLabel(len(r.Groups)), Length: 0, Count: 0})
+			shortest, total = s.Length, 0
+		}
+
+		group := &r.Groups[len(r.Groups)-1]
+		group.Count++
+		total += s.Length
+		group.Length = total / float64(group.Count)
+		s.Label = group.Label
+	}
+}
+
+// strutLabel returns the label of the nth length group: A to Z, then AA, AB, and so on.
+func strutLabel(n int) string {
+	label := ""
+
+	for n++; n > 0; n = (n - 1) / labelLetters {
+		label = string(rune('A'+(n-1)%labelLetters)) + label
+	}
+
+	return label
+}
+
+// newHub measures the struts meeting a vertex. It returns nil if the cut removed
+// all of them.
+func newHub(v *Vertex, center Vector3, scale float64, labels map[int]string) *Hub {
+	axis := v.Position.Sub(center).Normalize()
+	u, w := tangentBasis(axis)
+
+	type strutEnd struct {
+		strut   HubStrut
+		azimuth float64
+	}
+
+	var ends []strutEnd
+
+	for _, e := range v.Edges {
+		label, ok := labels[e.ID]
+		if !ok {
+			continue
+		}
+
+		direction := e.OtherVertex(v).Position.Sub(v.Position).Normalize()
+		ends = append(ends, strutEnd{
+			strut:   HubStrut{Edge: e.ID, Label: label, AxialAngle: -math.Asin(direction.Dot(axis)) * radiansToDegrees, DihedralAngle: 0},
+			azimuth: math.Atan2(direction.Dot(w), direction.Dot(u)),
+		})
+	}
+
+	if len(ends) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(ends, func(a, b strutEnd) int { return cmp.Compare(a.azimuth, b.azimuth) })
+
+	hub := &Hub{
+		Vertex:   v.ID,
+		Position: v.Position.Sub(center).Scale(scale),
+		Base:     len(ends) < len(v.Edges),
+		Struts:   make([]HubStrut, len(ends)),
+	}
+
+	for i, end := range ends {
+		turn := ends[(i+1)%len(ends)].azimuth - end.azimuth
+		if turn <= 0 {
+			turn += 2 * math.Pi
+		}
+
+		hub.Struts[i] = end.strut
+		hub.Struts[i].DihedralAngle = turn * radiansToDegrees
+	}
+
+	return hub
+}
+
+// tangentBasis returns two unit vectors spanning the plane perpendicular to axis,
+// ordered so that turning from the first to the second is counter-clockwise seen
+// from the side axis points to.
+func tangentBasis(axis Vector3) (Vector3, Vector3) {
+	reference := Vector3{X: 0, Y: 0, Z: 1}
+	if math.Abs(axis.Z) > math.Sqrt2/2 {
+		reference = Vector3{X: 1, Y: 0, Z: 0}
+	}
+
+	u := reference.Sub(axis.Scale(reference.Dot(axis))).Normalize()
+
+	return u, axis.Cross(u)
+}
+
+// WriteCSV writes one row per strut end: the hub's vertex ID, the strut's edge ID,
+// label and length, and its axial and dihedral angles in degrees.
+func (r *FabricationReport) WriteCSV(w io.Writer) error {
+	lengths := make(map[int]float64, len(r.Struts))
+
+	for _, s := range r.Struts {
+		lengths[s.Edge] = s.Length
+	}
+
+	cw := csv.NewWriter(w)
+
+	_ = cw.Write([]string{"hub", "edge", "label", "length", "axial_angle", "dihedral_angle"}) // Errors surface from Flush
+
+	for _, hub := range r.Hubs {
+		for _, s := range hub.Struts {
+			_ = cw.Write([]string{
+				strconv.Itoa(hub.Vertex), strconv.Itoa(s.Edge), s.Label,
+				formatDecimal(lengths[s.Edge], fabricationPrecision),
+				formatDecimal(s.AxialAngle, fabricationPrecision),
+				formatDecimal(s.DihedralAngle, fabricationPrecision),
+			})
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("writing fabrication CSV: %w", err)
+	}
+
+	return nil
+}
+
+// hubType is a set of hubs with the same struts and angles.
+type hubType struct {
+	struts []HubStrut
+	base   bool
+	count  int
+}
+
+// hubTypes groups the hubs by their struts' labels and angles, read from a canonical
+// starting strut: after the widest gap for base hubs, otherwise the rotation whose
+// description sorts first.
+func (r *FabricationReport) hubTypes() []*hubType {
+	var types []*hubType
+
+	byKey := make(map[string]*hubType)
+
+	for _, hub := range r.Hubs {
+		var struts []HubStrut
+
+		key := ""
+
+		for start := range hub.Struts {
+			if hub.Base && hub.Struts[(start+len(hub.Struts)-1)%len(hub.Struts)].DihedralAngle < maxDihedral(hub.Struts) {
+				continue
+			}
+
+			rotated := append(slices.Clone(hub.Struts[start:]), hub.Struts[:start]...)
+			if k := hubKey(rotated, hub.Base); key == "" || k < key {
+				key, struts = k, rotated
+			}
+		}
+
+		if t, ok := byKey[key]; ok {
+			t.count++
+
+			continue
+		}
+
+		t := &hubType{struts: struts, base: hub.Base, count: 1}
+		byKey[key] = t
+		types = append(types, t)
+	}
+
+	return types
+}
+
+// maxDihedral returns the widest dihedral angle among a hub's struts.
+func maxDihedral(struts []HubStrut) float64 {
+	widest := 0.0
+
+	for _, s := range struts {
+		widest = math.Max(widest, s.DihedralAngle)
+	}
+
+	return widest
+}
+
+// hubKey describes a hub's struts as written in the report.
+func hubKey(struts []HubStrut, base bool) string {
+	var sb strings.Builder
+
+	for _, s := range struts {
+		fmt.Fprintf(&sb, "%s %s %s;", s.Label,
+			formatDecimal(s.AxialAngle, fabricationPrecision), formatDecimal(s.DihedralAngle, fabricationPrecision))
+	}
+
+	if base {
+		sb.WriteString("base")
+	}
+
+	return sb.String()
+}
+
+// WriteReport writes a human-readable summary: the strut groups with their lengths
+// and counts, and each distinct hub type with its struts' angles.
+func (r *FabricationReport) WriteReport(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	types := r.hubTypes()
+	base := 0
+
+	for _, hub := range r.Hubs {
+		if hub.Base {
+			base++
+		}
+	}
+
+	fmt.Fprintf(bw, "Fabrication report for %s\n", r.Name)
+	fmt.Fprintf(bw, "Radius %s, %d struts, %d hubs (%d on the base)\n\n",
+		formatDecimal(r.Radius, fabricationPrecision), len(r.Struts), len(r.Hubs), base)
+
+	fmt.Fprintf(bw, "Struts\n%-6s %12s %6s\n", "Label", "Length", "Count")
+
+	for _, g := range r.Groups {
+		fmt.Fprintf(bw, "%-6s %12s %6d\n", g.Label, formatDecimal(g.Length, fabricationPrecision), g.Count)
+	}
+
+	for i, t := range types {
+		labels := make([]string, len(t.struts))
+
+		for j, s := range t.struts {
+			labels[j] = s.Label
+		}
+
+		kind := "hubs"
+		if t.base {
+			kind = "base hubs"
+		}
+
+		fmt.Fprintf(bw, "\nHub type %d: %s, %d %s\n%-6s %10s %10s\n", i+1, strings.Join(labels, "-"), t.count, kind, "Strut", "Axial", "Dihedral")
+
+		for _, s := range t.struts {
+			fmt.Fprintf(bw, "%-6s %10s %10s\n", s.Label,
+				formatDecimal(s.AxialAngle, fabricationPrecision), formatDecimal(s.DihedralAngle, fabricationPrecision))
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing fabrication report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package conway_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricate(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Icosahedron()
+	report, err := conway.Fabricate(p, conway.WithDomeRadius(2))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 2, report.Radius, 1e-9)
+	require.Len(t, report.Groups, 1)
+	assert.Equal(t, "A", report.Groups[0].Label)
+	assert.Equal(t, 30, report.Groups[0].Count)
+	assert.Len(t, report.Struts, 30)
+	require.Len(t, report.Hubs, 12)
+
+	// A strut between two points of a sphere dips below each hub's tangent plane by
+	// half the angle it subtends at the centre.
+	axial := math.Asin(report.Groups[0].Length/(2*report.Radius)) * 180 / math.Pi
+
+	for _, hub := range report.Hubs {
+		assert.False(t, hub.Base)
+		require.Len(t, hub.Struts, 5)
+
+		for _, s := range hub.Struts {
+			assert.InDelta(t, axial, s.AxialAngle, 1e-9)
+			assert.InDelta(t, 72, s.DihedralAngle, 1e-9)
+		}
+	}
+}
+
+func TestFabricateGroups(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("dtI")
+	report, err := conway.Fabricate(p)
+	require.NoError(t, err)
+
+	require.Len(t, report.Groups, 2, "pyramid edges and dodecahedron edges")
+	assert.Equal(t, []string{"A", "B"}, []string{report.Groups[0].Label, report.Groups[1].Label})
+	assert.Less(t, report.Groups[0].Length, report.Groups[1].Length)
+	assert.ElementsMatch(t, []int{30, 60}, []int{report.Groups[0].Count, report.Groups[1].Count})
+
+	for _, hub := range report.Hubs {
+		total := 0.0
+
+		for _, s := range hub.Struts {
+			total += s.DihedralAngle
+		}
+
+		assert.InDelta(t, 360, total, 1e-9, "dihedral angles go once around the hub")
+	}
+
+	// A loose tolerance merges every strut into one group.
+	report, err = conway.Fabricate(p, conway.WithLengthTolerance(1))
+	require.NoError(t, err)
+	assert.Len(t, report.Groups, 1)
+}
+
+func TestFabricateCutPlane(t *testing.T) {
+	t.Parallel()
+
+	// Cutting an icosahedron through its centre, square to a vertex axis, leaves the
+	// top vertex and the ring of five below it.
+	p := conway.Icosahedron()
+	center := p.Centroid()
+	top := p.Vertices[lowestVertexID(p)].Position.Sub(center)
+
+	report, err := conway.Fabricate(p, conway.WithCutPlane(center, top))
+	require.NoError(t, err)
+
+	assert.Len(t, report.Struts, 10)
+	require.Len(t, report.Hubs, 6)
+
+	base := 0
+
+	for _, hub := range report.Hubs {
+		if hub.Base {
+			base++
+
+			assert.Len(t, hub.Struts, 3)
+		}
+	}
+
+	assert.Equal(t, 5, base)
+
+	_, err = conway.Fabricate(p, conway.WithCutPlane(center.Add(top.Scale(2)), top))
+	require.ErrorIs(t, err, conway.ErrEmptyDome)
+}
+
+func TestFabricationOutput(t *testing.T) {
+	t.Parallel()
+
+	report, err := conway.Fabricate(conway.Icosahedron())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteCSV(&buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 1+2*30, "a header and a row per strut end")
+	assert.Equal(t, []string{"hub", "edge", "label", "length", "axial_angle", "dihedral_angle"}, rows[0])
+	assert.Equal(t, "72", rows[1][5])
+
+	var text strings.Builder
+
+	require.NoError(t, report.WriteReport(&text))
+	assert.Contains(t, text.String(), "30 struts, 12 hubs (0 on the base)")
+	assert.Contains(t, text.String(), "Hub type 1: A-A-A-A-A, 12 hubs")
+	assert.NotContains(t, text.String(), "Hub type 2")
+
+	require.ErrorIs(t, report.WriteCSV(failingWriter{}), errWriteFailed)
+	require.ErrorIs(t, report.WriteReport(failingWriter{}), errWriteFailed)
+}
+
+// lowestVertexID returns the smallest vertex ID of the polyhedron.
+func lowestVertexID(p *conway.Polyhedron) int {
+	lowest := math.MaxInt
+
+	for id := range p.Vertices {
+		lowest = min(lowest, id)
+	}
+
+	return lowest
+}
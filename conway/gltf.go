@@ -0,0 +1,499 @@
+package conway
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	// glTF component types and buffer view targets from the specification.
+	gltfFloat        = 5126
+	gltfUnsignedInt  = 5125
+	gltfArrayBuffer  = 34962
+	gltfElementArray = 34963
+	gltfTriangles    = 4
+
+	// GLB container constants.
+	glbMagic     = 0x46546c67 // "glTF"
+	glbVersion   = 2
+	glbChunkJSON = 0x4e4f534a // "JSON"
+	glbChunkBIN  = 0x004e4942 // "BIN\x00"
+	glbAlignment = 4
+
+	// defaultMetallic and defaultRoughness give a matte, non-metallic material.
+	defaultMetallic  = 0
+	defaultRoughness = 0.8
+)
+
+// NormalMode selects how vertex normals are computed for export.
+type NormalMode int
+
+const (
+	// FlatNormals gives every vertex of a face the face's normal, so faces look flat.
+	FlatNormals NormalMode = iota
+	// SmoothNormals averages the normals of the faces around each vertex, weighted by
+	// face area, so the surface looks curved.
+	SmoothNormals
+)
+
+// ColorMode selects how face colours are exported.
+type ColorMode int
+
+const (
+	// VertexColors writes each face's ColorAttribute as the colour of its vertices.
+	VertexColors ColorMode = iota
+	// MaterialColors groups faces by colour into primitives with one material each.
+	MaterialColors
+)
+
+// GLTFOption configures glTF export.
+type GLTFOption func(*gltfConfig)
+
+// gltfConfig holds glTF export settings.
+type gltfConfig struct {
+	normals NormalMode
+	colors  ColorMode
+}
+
+func newGLTFConfig(opts []GLTFOption) gltfConfig {
+	cfg := gltfConfig{normals: FlatNormals, colors: VertexColors}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithNormals sets how vertex normals are computed. The default is FlatNormals.
+func WithNormals(mode NormalMode) GLTFOption {
+	return func(cfg *gltfConfig) {
+		cfg.normals = mode
+	}
+}
+
+// WithColorMode sets how face colours are exported. The default is VertexColors.
+func WithColorMode(mode ColorMode) GLTFOption {
+	return func(cfg *gltfConfig) {
+		cfg.colors = mode
+	}
+}
+
+// WriteGLTF writes the polyhedron as a glTF 2.0 JSON document with its binary buffer
+// embedded as a base64 data URI. Faces are triangulated by ear clipping, every face
+// gets its own vertices so normals and colours can differ between faces, and the mesh
+// and its node are named after the polyhedron. Faces with a ColorAttribute are
+// coloured as set by WithColorMode; faces without one are white with vertex colours,
+// or take a default grey material. Faces keep their own winding order, so faces wound
+// inwards, as reported by ValidateWinding, are exported inside-out.
+func WriteGLTF(w io.Writer, p *Polyhedron, opts ...GLTFOption) error {
+	doc, bin := buildGLTF(p, newGLTFConfig(opts))
+	doc.Buffers[0].URI = "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(bin)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("writing glTF: %w", err)
+	}
+
+	return nil
+}
+
+// WriteGLB writes the polyhedron as a binary glTF 2.0 (.glb) file, with the same
+// content as WriteGLTF but the buffer stored in a binary chunk.
+func WriteGLB(w io.Writer, p *Polyhedron, opts ...GLTFOption) error {
+	doc, bin := buildGLTF(p, newGLTFConfig(opts))
+
+	js, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("writing GLB: %w", err)
+	}
+
+	js = padTo(js, ' ')
+	bin = padTo(bin, 0)
+
+	const headerSize, chunkHeaderSize = 12, 8
+
+	bw := bufio.NewWriter(w)
+	header := []uint32{glbMagic, glbVersion, uint32(headerSize + chunkHeaderSize + len(js) + chunkHeaderSize + len(bin))}
+
+	_ = binary.Write(bw, binary.LittleEndian, header) // Writes to the buffered writer cannot fail until Flush
+	_ = binary.Write(bw, binary.LittleEndian, []uint32{uint32(len(js)), glbChunkJSON})
+	bw.Write(js)
+	_ = binary.Write(bw, binary.LittleEndian, []uint32{uint32(len(bin)), glbChunkBIN})
+	bw.Write(bin)
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing GLB: %w", err)
+	}
+
+	return nil
+}
+
+// padTo pads data with the given byte to a multiple of the GLB chunk alignment.
+func padTo(data []byte, pad byte) []byte {
+	for len(data)%glbAlignment != 0 {
+		data = append(data, pad)
+	}
+
+	return data
+}
+
+// gltfDocument is the subset of the glTF 2.0 schema written by the exporter.
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes"`
+	Materials   []gltfMaterial   `json:"materials,omitempty"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfScene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type gltfNode struct {
+	Name string `json:"name,omitempty"`
+	Mesh int    `json:"mesh"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name,omitempty"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    int            `json:"indices"`
+	Material   *int           `json:"material,omitempty"`
+	Mode       int            `json:"mode"`
+}
+
+type gltfMaterial struct {
+	Name                 string  `json:"name,omitempty"`
+	PBRMetallicRoughness gltfPBR `json:"pbrMetallicRoughness"`
+	AlphaMode            string  `json:"alphaMode,omitempty"`
+}
+
+type gltfPBR struct {
+	BaseColorFactor [4]float64 `json:"baseColorFactor"`
+	MetallicFactor  float64    `json:"metallicFactor"`
+	RoughnessFactor float64    `json:"roughnessFactor"`
+}
+
+type gltfAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float64 `json:"min,omitempty"`
+	Max           []float64 `json:"max,omitempty"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target,omitempty"`
+}
+
+type gltfBuffer struct {
+	URI        string `json:"uri,omitempty"`
+	ByteLength int    `json:"byteLength"`
+}
+
+// gltfBuilder accumulates the binary buffer and the accessors describing it.
+type gltfBuilder struct {
+	doc *gltfDocument
+	bin bytes.Buffer
+}
+
+// addFloats appends a vertex attribute of float components, returning its accessor.
+func (b *gltfBuilder) addFloats(values [][]float64, kind string, bounds bool) int {
+	view := b.addView(gltfArrayBuffer, func(buf *bytes.Buffer) {
+		for _, v := range values {
+			for _, c := range v {
+				_ = binary.Write(buf, binary.LittleEndian, float32(c)) // bytes.Buffer writes cannot fail
+			}
+		}
+	})
+
+	accessor := gltfAccessor{BufferView: view, ComponentType: gltfFloat, Count: len(values), Type: kind, Min: nil, Max: nil}
+
+	if bounds && len(values) > 0 {
+		accessor.Min, accessor.Max = componentBounds(values)
+	}
+
+	b.doc.Accessors = append(b.doc.Accessors, accessor)
+
+	return len(b.doc.Accessors) - 1
+}
+
+// addIndices appends triangle indices, returning their accessor.
+func (b *gltfBuilder) addIndices(indices []uint32) int {
+	view := b.addView(gltfElementArray, func(buf *bytes.Buffer) {
+		_ = binary.Write(buf, binary.LittleEndian, indices) // bytes.Buffer writes cannot fail
+	})
+
+	b.doc.Accessors = append(b.doc.Accessors, gltfAccessor{
+		BufferView: view, ComponentType: gltfUnsignedInt, Count: len(indices), Type: "SCALAR", Min: nil, Max: nil,
+	})
+
+	return len(b.doc.Accessors) - 1
+}
+
+// addView appends data to the buffer as a new buffer view. Every component is four
+// bytes, so views stay aligned without padding.
+func (b *gltfBuilder) addView(target int, write func(*bytes.Buffer)) int {
+	offset := b.bin.Len()
+	write(&b.bin)
+
+	b.doc.BufferViews = append(b.doc.BufferViews, gltfBufferView{
+		Buffer: 0, ByteOffset: offset, ByteLength: b.bin.Len() - offset, Target: target,
+	})
+
+	return len(b.doc.BufferViews) - 1
+}
+
+// componentBounds returns the per-component minimum and maximum of the values,
+// rounded to float32 as stored, as required for POSITION accessors.
+func componentBounds(values [][]float64) ([]float64, []float64) {
+	lo := make([]float64, len(values[0]))
+	hi := make([]float64, len(values[0]))
+
+	for i := range lo {
+		lo[i], hi[i] = math.Inf(1), math.Inf(-1)
+	}
+
+	for _, v := range values {
+		for i, c := range v {
+			c = float64(float32(c))
+			lo[i], hi[i] = math.Min(lo[i], c), math.Max(hi[i], c)
+		}
+	}
+
+	return lo, hi
+}
+
+// gltfGroup is one primitive: the faces sharing a material, or all faces.
+type gltfGroup struct {
+	color    Color
+	hasColor bool
+	faces    []*Face
+}
+
+// buildGLTF converts the polyhedron to a glTF document and its binary buffer.
+func buildGLTF(p *Polyhedron, cfg gltfConfig) (*gltfDocument, []byte) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	doc := &gltfDocument{
+		Asset:       gltfAsset{Version: "2.0", Generator: "github.com/sksmith/conway"},
+		Scene:       0,
+		Scenes:      []gltfScene{{Nodes: []int{0}}},
+		Nodes:       []gltfNode{{Name: p.Name, Mesh: 0}},
+		Meshes:      []gltfMesh{{Name: p.Name, Primitives: nil}},
+		Materials:   nil,
+		Accessors:   nil,
+		BufferViews: nil,
+		Buffers:     nil,
+	}
+
+	b := &gltfBuilder{doc: doc, bin: bytes.Buffer{}}
+	faces := sortedFaces(p)
+	normals := faceNormals(faces)
+
+	var vertexNormals map[int]Vector3
+	if cfg.normals == SmoothNormals {
+		vertexNormals = smoothNormals(faces, normals)
+	}
+
+	for _, group := range gltfGroups(faces, cfg.colors) {
+		var positions, vertexNormalData, colors [][]float64
+
+		var indices []uint32
+
+		for _, f := range group.faces {
+			base := uint32(len(positions))
+			normal := normals[f.ID]
+			c, hasColor := ColorAttribute().Get(f)
+
+			if !hasColor {
+				c = RGB(1, 1, 1)
+			}
+
+			for _, v := range f.Vertices {
+				n := normal
+				if vertexNormals != nil {
+					n = vertexNormals[v.ID]
+				}
+
+				positions = append(positions, []float64{v.Position.X, v.Position.Y, v.Position.Z})
+				vertexNormalData = append(vertexNormalData, []float64{n.X, n.Y, n.Z})
+				colors = append(colors, []float64{clampChannel(c.R), clampChannel(c.G), clampChannel(c.B), clampChannel(c.A)})
+			}
+
+			indices = append(indices, faceTriangles(f, base)...)
+		}
+
+		attributes := map[string]int{
+			"POSITION": b.addFloats(positions, "VEC3", true),
+			"NORMAL":   b.addFloats(vertexNormalData, "VEC3", false),
+		}
+
+		if cfg.colors == VertexColors && group.hasColor {
+			attributes["COLOR_0"] = b.addFloats(colors, "VEC4", false)
+		}
+
+		primitive := gltfPrimitive{Attributes: attributes, Indices: b.addIndices(indices), Material: nil, Mode: gltfTriangles}
+
+		if cfg.colors == MaterialColors {
+			material := len(doc.Materials)
+			primitive.Material = &material
+			doc.Materials = append(doc.Materials, gltfMaterialFor(group))
+		}
+
+		doc.Meshes[0].Primitives = append(doc.Meshes[0].Primitives, primitive)
+	}
+
+	doc.Buffers = []gltfBuffer{{URI: "", ByteLength: b.bin.Len()}}
+
+	return doc, b.bin.Bytes()
+}
+
+// gltfGroups splits the faces into primitives: one per distinct colour, in order of
+// first appearance, for material colours, otherwise a single primitive that has
+// colours if any face does.
+func gltfGroups(faces []*Face, mode ColorMode) []gltfGroup {
+	colorKey := ColorAttribute()
+
+	if mode != MaterialColors {
+		group := gltfGroup{color: Color{R: 0, G: 0, B: 0, A: 0}, hasColor: false, faces: faces}
+
+		for _, f := range faces {
+			if _, ok := colorKey.Get(f); ok {
+				group.hasColor = true
+			}
+		}
+
+		return []gltfGroup{group}
+	}
+
+	var groups []gltfGroup
+
+	index := make(map[Color]int)
+	uncolored := -1
+
+	for _, f := range faces {
+		c, ok := colorKey.Get(f)
+
+		var slot int
+
+		switch {
+		case !ok && uncolored >= 0:
+			slot = uncolored
+		case !ok:
+			uncolored = len(groups)
+			slot = uncolored
+			groups = append(groups, gltfGroup{color: DefaultStyle().Fill, hasColor: false, faces: nil})
+		default:
+			existing, seen := index[c]
+			if !seen {
+				existing = len(groups)
+				index[c] = existing
+				groups = append(groups, gltfGroup{color: c, hasColor: true, faces: nil})
+			}
+
+			slot = existing
+		}
+
+		groups[slot].faces = append(groups[slot].faces, f)
+	}
+
+	return groups
+}
+
+// gltfMaterialFor returns the material for a group of faces.
+func gltfMaterialFor(group gltfGroup) gltfMaterial {
+	c := group.color
+	material := gltfMaterial{
+		Name: c.Hex(),
+		PBRMetallicRoughness: gltfPBR{
+			BaseColorFactor: [4]float64{clampChannel(c.R), clampChannel(c.G), clampChannel(c.B), clampChannel(c.A)},
+			MetallicFactor:  defaultMetallic,
+			RoughnessFactor: defaultRoughness,
+		},
+		AlphaMode: "",
+	}
+
+	if !group.hasColor {
+		material.Name = "default"
+	}
+
+	if c.A < 1 {
+		material.AlphaMode = "BLEND"
+	}
+
+	return material
+}
+
+// faceNormals returns the unit normal of every face by face ID.
+func faceNormals(faces []*Face) map[int]Vector3 {
+	normals := make(map[int]Vector3, len(faces))
+
+	for _, f := range faces {
+		normals[f.ID] = f.Normal()
+	}
+
+	return normals
+}
+
+// smoothNormals returns, for every vertex, the area-weighted average of the outward
+// normals of its faces.
+func smoothNormals(faces []*Face, normals map[int]Vector3) map[int]Vector3 {
+	sums := make(map[int]Vector3)
+
+	for _, f := range faces {
+		weighted := normals[f.ID].Scale(f.Area())
+
+		for _, v := range f.Vertices {
+			sums[v.ID] = sums[v.ID].Add(weighted)
+		}
+	}
+
+	for id, sum := range sums {
+		sums[id] = sum.Normalize()
+	}
+
+	return sums
+}
+
+// faceTriangles triangulates a face by ear clipping, with indices offset by base.
+// Triangles keep the face's winding, counter-clockwise seen from outside as glTF
+// requires.
+func faceTriangles(f *Face, base uint32) []uint32 {
+	triangles := f.Triangulate(EarClipping)
+	indices := make([]uint32, 0, 3*len(triangles))
+
+	for _, tri := range triangles {
+		indices = append(indices, base+uint32(tri[0]), base+uint32(tri[1]), base+uint32(tri[2]))
+	}
+
+	return indices
+}
@@ -0,0 +1,235 @@
+package conway_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gltfFile is the subset of a glTF document inspected by the tests.
+type gltfFile struct {
+	Asset struct {
+		Version string `json:"version"`
+	} `json:"asset"`
+	Nodes []struct {
+		Name string `json:"name"`
+		Mesh int    `json:"mesh"`
+	} `json:"nodes"`
+	Meshes []struct {
+		Primitives []struct {
+			Attributes map[string]int `json:"attributes"`
+			Indices    int            `json:"indices"`
+			Material   *int           `json:"material"`
+		} `json:"primitives"`
+	} `json:"meshes"`
+	Materials []struct {
+		PBR struct {
+			BaseColorFactor []float64 `json:"baseColorFactor"`
+		} `json:"pbrMetallicRoughness"`
+	} `json:"materials"`
+	Accessors []struct {
+		BufferView int       `json:"bufferView"`
+		Count      int       `json:"count"`
+		Type       string    `json:"type"`
+		Min        []float64 `json:"min"`
+		Max        []float64 `json:"max"`
+	} `json:"accessors"`
+	BufferViews []struct {
+		ByteOffset int `json:"byteOffset"`
+		ByteLength int `json:"byteLength"`
+	} `json:"bufferViews"`
+	Buffers []struct {
+		URI        string `json:"uri"`
+		ByteLength int    `json:"byteLength"`
+	} `json:"buffers"`
+}
+
+func readGLTF(t *testing.T, p *conway.Polyhedron, opts ...conway.GLTFOption) (gltfFile, []byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, conway.WriteGLTF(&buf, p, opts...))
+
+	var doc gltfFile
+
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	require.Len(t, doc.Buffers, 1)
+
+	uri, ok := strings.CutPrefix(doc.Buffers[0].URI, "data:application/octet-stream;base64,")
+	require.True(t, ok)
+
+	bin, err := base64.StdEncoding.DecodeString(uri)
+	require.NoError(t, err)
+	require.Len(t, bin, doc.Buffers[0].ByteLength)
+
+	return doc, bin
+}
+
+// floats returns the float32 components of an accessor.
+func (doc gltfFile) floats(bin []byte, accessor int) []float32 {
+	view := doc.BufferViews[doc.Accessors[accessor].BufferView]
+	values := make([]float32, view.ByteLength/4)
+	_ = binary.Read(bytes.NewReader(bin[view.ByteOffset:view.ByteOffset+view.ByteLength]), binary.LittleEndian, values)
+
+	return values
+}
+
+// indices returns the uint32 indices of an accessor.
+func (doc gltfFile) indices(bin []byte, accessor int) []uint32 {
+	view := doc.BufferViews[doc.Accessors[accessor].BufferView]
+	values := make([]uint32, view.ByteLength/4)
+	_ = binary.Read(bytes.NewReader(bin[view.ByteOffset:view.ByteOffset+view.ByteLength]), binary.LittleEndian, values)
+
+	return values
+}
+
+func TestWriteGLTF(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("tC")
+	doc, bin := readGLTF(t, p)
+
+	assert.Equal(t, "2.0", doc.Asset.Version)
+	require.Len(t, doc.Nodes, 1)
+	assert.Equal(t, p.Name, doc.Nodes[0].Name)
+	require.Len(t, doc.Meshes, 1)
+	require.Len(t, doc.Meshes[0].Primitives, 1)
+
+	prim := doc.Meshes[0].Primitives[0]
+	assert.NotContains(t, prim.Attributes, "COLOR_0", "no colours without colour attributes")
+
+	// Eight triangles and six octagons, each with its own vertices.
+	positions := doc.floats(bin, prim.Attributes["POSITION"])
+	normals := doc.floats(bin, prim.Attributes["NORMAL"])
+	indices := doc.indices(bin, prim.Indices)
+
+	assert.Len(t, positions, 3*(8*3+6*8))
+	assert.Len(t, normals, len(positions))
+	assert.Len(t, indices, 3*(8*1+6*6))
+	assert.Len(t, doc.Accessors[prim.Attributes["POSITION"]].Min, 3)
+	assert.Len(t, doc.Accessors[prim.Attributes["POSITION"]].Max, 3)
+
+	vertex := func(i uint32) conway.Vector3 {
+		return conway.Vector3{X: float64(positions[3*i]), Y: float64(positions[3*i+1]), Z: float64(positions[3*i+2])}
+	}
+
+	// Triangles wind counter-clockwise seen from outside, matching their flat normals.
+	center := p.Centroid()
+
+	for i := 0; i < len(indices); i += 3 {
+		a, b, c := vertex(indices[i]), vertex(indices[i+1]), vertex(indices[i+2])
+		n := b.Sub(a).Cross(c.Sub(a))
+
+		assert.Positive(t, n.Dot(a.Sub(center)))
+
+		stored := conway.Vector3{X: float64(normals[3*indices[i]]), Y: float64(normals[3*indices[i]+1]), Z: float64(normals[3*indices[i]+2])}
+		assert.InDelta(t, 1, stored.Dot(n.Normalize()), 1e-5)
+	}
+}
+
+func TestWriteGLTFSmoothNormals(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+	doc, bin := readGLTF(t, p, conway.WithNormals(conway.SmoothNormals))
+	prim := doc.Meshes[0].Primitives[0]
+	positions := doc.floats(bin, prim.Attributes["POSITION"])
+	normals := doc.floats(bin, prim.Attributes["NORMAL"])
+
+	// Every cube corner's smooth normal points along its diagonal.
+	for i := 0; i < len(positions); i += 3 {
+		pos := conway.Vector3{X: float64(positions[i]), Y: float64(positions[i+1]), Z: float64(positions[i+2])}
+		n := conway.Vector3{X: float64(normals[i]), Y: float64(normals[i+1]), Z: float64(normals[i+2])}
+
+		assert.InDelta(t, 1, n.Dot(pos.Normalize()), 1e-5)
+	}
+}
+
+func TestWriteGLTFColors(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("tC")
+	conway.DegreeColoring(p).Apply(p, conway.DefaultPalette())
+
+	doc, bin := readGLTF(t, p)
+	prim := doc.Meshes[0].Primitives[0]
+
+	require.Contains(t, prim.Attributes, "COLOR_0")
+	assert.Equal(t, "VEC4", doc.Accessors[prim.Attributes["COLOR_0"]].Type)
+
+	colors := doc.floats(bin, prim.Attributes["COLOR_0"])
+	distinct := make(map[[4]float32]bool)
+
+	for i := 0; i < len(colors); i += 4 {
+		distinct[[4]float32{colors[i], colors[i+1], colors[i+2], colors[i+3]}] = true
+	}
+
+	assert.Len(t, distinct, 2)
+
+	doc, _ = readGLTF(t, p, conway.WithColorMode(conway.MaterialColors))
+
+	require.Len(t, doc.Meshes[0].Primitives, 2, "one primitive per colour")
+	require.Len(t, doc.Materials, 2)
+
+	for i, prim := range doc.Meshes[0].Primitives {
+		assert.NotContains(t, prim.Attributes, "COLOR_0")
+		require.NotNil(t, prim.Material)
+		assert.Equal(t, i, *prim.Material)
+	}
+
+	// Materials are in order of first appearance among the faces in ID order.
+	first, _ := conway.ColorAttribute().Get(p.Faces[lowestFaceID(p)])
+	assert.InDeltaSlice(t, []float64{first.R, first.G, first.B, 1}, doc.Materials[0].PBR.BaseColorFactor, 1e-9)
+}
+
+func TestWriteGLB(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Dodecahedron()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, conway.WriteGLB(&buf, p))
+
+	data := buf.Bytes()
+	require.GreaterOrEqual(t, len(data), 20)
+
+	header := make([]uint32, 5)
+	require.NoError(t, binary.Read(bytes.NewReader(data), binary.LittleEndian, header))
+
+	assert.Equal(t, uint32(0x46546c67), header[0], "magic")
+	assert.Equal(t, uint32(2), header[1], "version")
+	assert.Equal(t, uint32(len(data)), header[2], "total length")
+	assert.Equal(t, uint32(0x4e4f534a), header[4], "JSON chunk")
+	assert.Zero(t, header[3]%4, "chunks are 4-byte aligned")
+
+	jsonEnd := 20 + int(header[3])
+
+	var doc gltfFile
+
+	require.NoError(t, json.Unmarshal(data[20:jsonEnd], &doc))
+	assert.Empty(t, doc.Buffers[0].URI, "the GLB buffer is the binary chunk")
+	assert.Equal(t, p.Name, doc.Nodes[0].Name)
+
+	binHeader := make([]uint32, 2)
+	require.NoError(t, binary.Read(bytes.NewReader(data[jsonEnd:]), binary.LittleEndian, binHeader))
+	assert.Equal(t, uint32(0x004e4942), binHeader[1], "BIN chunk")
+	assert.GreaterOrEqual(t, int(binHeader[0]), doc.Buffers[0].ByteLength)
+	assert.Equal(t, len(data), jsonEnd+8+int(binHeader[0]))
+
+	// Twelve pentagons, three triangles each.
+	indices := doc.Accessors[doc.Meshes[0].Primitives[0].Indices]
+	assert.Equal(t, 12*3*3, indices.Count)
+	assert.False(t, math.IsNaN(doc.Accessors[0].Min[0]))
+
+	assert.ErrorIs(t, conway.WriteGLB(failingWriter{}, p), errWriteFailed)
+}
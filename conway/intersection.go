@@ -0,0 +1,355 @@
+package conway
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// bvhLeafSize is the largest number of boxes in a leaf of a bounding volume hierarchy.
+const bvhLeafSize = 4
+
+// SelfIntersections returns every pair of faces whose interiors cross, as face IDs
+// with the lower first, sorted. Faces are triangulated by ear clipping and triangle
+// pairs with overlapping bounding boxes, found with a bounding volume hierarchy, are
+// tested exactly. Faces that only meet along their shared edges and vertices, as
+// neighbouring faces do, do not intersect.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) SelfIntersections() [][2]int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.selfIntersectionsUnsafe(DefaultValidationTolerances().Intersection)
+}
+
+// selfIntersectionsUnsafe finds intersecting face pairs, taking faces within relative
+// tolerance of each other to touch. It performs no locking.
+func (p *Polyhedron) selfIntersectionsUnsafe(relativeTolerance float64) [][2]int {
+	var triangles []intersectionTriangle
+
+	lo := Vector3{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)}
+	hi := Vector3{X: math.Inf(-1), Y: math.Inf(-1), Z: math.Inf(-1)}
+
+	for _, f := range sortedFaces(p) {
+		for _, tri := range f.Triangulate(EarClipping) {
+			corners := [3]Vector3{f.Vertices[tri[0]].Position, f.Vertices[tri[1]].Position, f.Vertices[tri[2]].Position}
+			triangles = append(triangles, intersectionTriangle{face: f.ID, corners: corners})
+
+			for _, c := range corners {
+				updateBoundingBox(&lo, &hi, &c)
+			}
+		}
+	}
+
+	if len(triangles) == 0 {
+		return nil
+	}
+
+	tolerance := relativeTolerance * hi.Distance(lo)
+	boxes := make([][2]Vector3, len(triangles))
+
+	for i, t := range triangles {
+		boxes[i] = t.bounds(tolerance)
+	}
+
+	tree := newBVH(boxes)
+	found := make(map[[2]int]bool)
+
+	for i, t := range triangles {
+		tree.query(boxes[i], func(j int) {
+			u := triangles[j]
+
+			if j <= i || u.face == t.face || found[facePair(t.face, u.face)] {
+				return
+			}
+
+			if trianglesIntersect(t.corners, u.corners, tolerance) {
+				found[facePair(t.face, u.face)] = true
+			}
+		})
+	}
+
+	pairs := make([][2]int, 0, len(found))
+
+	for pair := range found {
+		pairs = append(pairs, pair)
+	}
+
+	slices.SortFunc(pairs, func(a, b [2]int) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+
+	return pairs
+}
+
+// ValidateSelfIntersection checks that no two faces cross each other, listing every
+// intersecting pair found by SelfIntersections.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) ValidateSelfIntersection() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	issues := p.selfIntersectionIssues(DefaultValidationTolerances().Intersection)
+
+	return joinIssues(checkSelfIntersection, fmt.Sprintf("%d intersecting face pairs", len(issues)), issues)
+}
+
+// selfIntersectionIssues reports each intersecting pair of faces.
+func (p *Polyhedron) selfIntersectionIssues(tolerance float64) []ValidationIssue {
+	pairs := p.selfIntersectionsUnsafe(tolerance)
+	issues := make([]ValidationIssue, len(pairs))
+
+	for i, pair := range pairs {
+		issues[i] = ValidationIssue{
+			Check:     checkSelfIntersection,
+			Severity:  SeverityError,
+			Element:   FaceElement.String(),
+			IDs:       []int{pair[0], pair[1]},
+			Message:   fmt.Sprintf("Faces %d and %d intersect", pair[0], pair[1]),
+			Value:     0,
+			Tolerance: tolerance,
+		}
+	}
+
+	return issues
+}
+
+// facePair orders two face IDs lowest first.
+func facePair(a, b int) [2]int {
+	return [2]int{min(a, b), max(a, b)}
+}
+
+// intersectionTriangle is one triangle of a face.
+type intersectionTriangle struct {
+	face    int
+	corners [3]Vector3
+}
+
+// bounds returns the triangle's bounding box grown by margin on every side.
+func (t intersectionTriangle) bounds(margin float64) [2]Vector3 {
+	lo, hi := t.corners[0], t.corners[0]
+
+	for _, c := range t.corners[1:] {
+		updateBoundingBox(&lo, &hi, &c)
+	}
+
+	grow := Vector3{X: margin, Y: margin, Z: margin}
+
+	return [2]Vector3{lo.Sub(grow), hi.Add(grow)}
+}
+
+// trianglesIntersect reports whether two triangles cross at points interior to both,
+// ignoring contact within tolerance, such as along a shared edge or at a shared
+// corner. Triangles in different planes cross exactly when an edge of one passes
+// through the other; triangles in the same plane cross when their edges do or one
+// lies inside the other.
+func trianglesIntersect(a, b [3]Vector3, tolerance float64) bool {
+	normal := a[1].Sub(a[0]).Cross(a[2].Sub(a[0])).Normalize()
+	coplanar := true
+
+	for _, c := range b {
+		if math.Abs(normal.Dot(c.Sub(a[0]))) > tolerance {
+			coplanar = false
+
+			break
+		}
+	}
+
+	if coplanar {
+		return coplanarTrianglesOverlap(a, b, normal, tolerance)
+	}
+
+	for i := range 3 {
+		if segmentCrossesTriangle(a[i], a[(i+1)%3], b, tolerance) ||
+			segmentCrossesTriangle(b[i], b[(i+1)%3], a, tolerance) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// segmentCrossesTriangle reports whether segment pq passes through the triangle's
+// plane strictly between its ends, at a point strictly inside the triangle.
+func segmentCrossesTriangle(p, q Vector3, t [3]Vector3, tolerance float64) bool {
+	normal := t[1].Sub(t[0]).Cross(t[2].Sub(t[0])).Normalize()
+	dp, dq := normal.Dot(p.Sub(t[0])), normal.Dot(q.Sub(t[0]))
+
+	if math.Abs(dp) <= tolerance || math.Abs(dq) <= tolerance || (dp > 0) == (dq > 0) {
+		return false
+	}
+
+	point := p.Add(q.Sub(p).Scale(dp / (dp - dq)))
+
+	return strictlyInsideTriangle(point, t, normal, tolerance)
+}
+
+// coplanarTrianglesOverlap reports whether two triangles in the plane with the given
+// normal share interior area.
+func coplanarTrianglesOverlap(a, b [3]Vector3, normal Vector3, tolerance float64) bool {
+	for i := range 3 {
+		for j := range 3 {
+			p, q := a[i], a[(i+1)%3]
+			r, s := b[j], b[(j+1)%3]
+
+			if planeSide(p, q, r, normal, tolerance)*planeSide(p, q, s, normal, tolerance) < 0 &&
+				planeSide(r, s, p, normal, tolerance)*planeSide(r, s, q, normal, tolerance) < 0 {
+				return true
+			}
+		}
+	}
+
+	// Without crossing edges, the triangles overlap only if one contains the other.
+	return strictlyInsideTriangle(triangleCentroid(a), b, normal, tolerance) ||
+		strictlyInsideTriangle(triangleCentroid(b), a, normal, tolerance)
+}
+
+// strictlyInsideTriangle reports whether a point in the triangle's plane lies inside
+// it and further than tolerance from its edges.
+func strictlyInsideTriangle(point Vector3, t [3]Vector3, normal Vector3, tolerance float64) bool {
+	first := planeSide(t[0], t[1], point, normal, tolerance)
+
+	return first != 0 &&
+		planeSide(t[1], t[2], point, normal, tolerance) == first &&
+		planeSide(t[2], t[0], point, normal, tolerance) == first
+}
+
+// planeSide returns 1 or -1 for the side of line pq that r lies on, turning about
+// normal, or 0 if r is within tolerance of the line.
+func planeSide(p, q, r, normal Vector3, tolerance float64) int {
+	length := q.Distance(p)
+	if length == 0 {
+		return 0
+	}
+
+	dist := q.Sub(p).Cross(r.Sub(p)).Dot(normal) / length
+
+	switch {
+	case dist > tolerance:
+		return 1
+	case dist < -tolerance:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// triangleCentroid returns the mean of a triangle's corners.
+func triangleCentroid(t [3]Vector3) Vector3 {
+	return t[0].Add(t[1]).Add(t[2]).Scale(1.0 / 3)
+}
+
+// bvh is a bounding volume hierarchy over boxes, splitting at the median along the
+// longest axis of the box centres until leaves hold at most bvhLeafSize boxes.
+type bvh struct {
+	boxes [][2]Vector3
+	order []int // Box indices, grouped by leaf
+	nodes []bvhNode
+}
+
+// bvhNode bounds a run of order. Leaves have no children.
+type bvhNode struct {
+	bounds      [2]Vector3
+	first, last int // Range of order covered by the node
+	left, right int // Child node indices, or -1 in a leaf
+}
+
+func newBVH(boxes [][2]Vector3) *bvh {
+	tree := &bvh{boxes: boxes, order: make([]int, len(boxes)), nodes: nil}
+
+	for i := range tree.order {
+		tree.order[i] = i
+	}
+
+	if len(boxes) > 0 {
+		tree.build(0, len(boxes))
+	}
+
+	return tree
+}
+
+// build adds the node covering order[first:last] and its descendants, returning its
+// index.
+func (t *bvh) build(first, last int) int {
+	lo, hi := t.boxes[t.order[first]][0], t.boxes[t.order[first]][1]
+	centreLo, centreHi := boxCentre(t.boxes[t.order[first]]), boxCentre(t.boxes[t.order[first]])
+
+	for _, i := range t.order[first+1 : last] {
+		updateBoundingBox(&lo, &hi, &t.boxes[i][0])
+		updateBoundingBox(&lo, &hi, &t.boxes[i][1])
+
+		centre := boxCentre(t.boxes[i])
+		updateBoundingBox(&centreLo, &centreHi, &centre)
+	}
+
+	index := len(t.nodes)
+	t.nodes = append(t.nodes, bvhNode{bounds: [2]Vector3{lo, hi}, first: first, last: last, left: -1, right: -1})
+
+	if last-first <= bvhLeafSize {
+		return index
+	}
+
+	extent := centreHi.Sub(centreLo)
+	axis := func(v Vector3) float64 { return v.X }
+
+	switch {
+	case extent.Y >= extent.X && extent.Y >= extent.Z:
+		axis = func(v Vector3) float64 { return v.Y }
+	case extent.Z >= extent.X && extent.Z >= extent.Y:
+		axis = func(v Vector3) float64 { return v.Z }
+	}
+
+	slices.SortFunc(t.order[first:last], func(a, b int) int {
+		return cmp.Compare(axis(boxCentre(t.boxes[a])), axis(boxCentre(t.boxes[b])))
+	})
+
+	middle := (first + last) / 2
+	left := t.build(first, middle)
+	right := t.build(middle, last)
+	t.nodes[index].left, t.nodes[index].right = left, right
+
+	return index
+}
+
+// query calls visit with the index of every box overlapping box.
+func (t *bvh) query(box [2]Vector3, visit func(int)) {
+	if len(t.nodes) == 0 {
+		return
+	}
+
+	stack := []int{0}
+
+	for len(stack) > 0 {
+		node := t.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+
+		if !boxesOverlap(node.bounds, box) {
+			continue
+		}
+
+		if node.left < 0 {
+			for _, i := range t.order[node.first:node.last] {
+				if boxesOverlap(t.boxes[i], box) {
+					visit(i)
+				}
+			}
+
+			continue
+		}
+
+		stack = append(stack, node.left, node.right)
+	}
+}
+
+// boxCentre returns the centre of a box.
+func boxCentre(box [2]Vector3) Vector3 {
+	return box[0].Add(box[1]).Scale(0.5)
+}
+
+// boxesOverlap reports whether two boxes share any point.
+func boxesOverlap(a, b [2]Vector3) bool {
+	return a[0].X <= b[1].X && b[0].X <= a[1].X &&
+		a[0].Y <= b[1].Y && b[0].Y <= a[1].Y &&
+		a[0].Z <= b[1].Z && b[0].Z <= a[1].Z
+}
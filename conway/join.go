@@ -1,6 +1,9 @@
 package conway
 
-type JoinOp struct{}
+// JoinOp is the dual of ambo. Workers is passed to the underlying operations.
+type JoinOp struct {
+	Workers int
+}
 
 func (j JoinOp) Symbol() string {
 	return "j"
@@ -11,9 +14,11 @@ func (j JoinOp) Name() string {
 }
 
 func (j JoinOp) Apply(p *Polyhedron) *Polyhedron {
-	dual := Dual(p)
+	dual := DualOp{Workers: j.Workers}.Apply(p)
 
-	ambo := Ambo(dual)
+	ambo := AmboOp{Workers: j.Workers}.Apply(dual)
+	ambo.Provenance = ambo.Provenance.collapse(2, j.Symbol())
+	propagateAttributes(p, ambo)
 
 	return ambo
 }
@@ -0,0 +1,254 @@
+package conway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+const (
+	// jsonFormatVersion is the current version of the JSON polyhedron encoding.
+	jsonFormatVersion = 1
+)
+
+// Static errors for err113 compliance.
+var (
+	ErrInvalidJSON = errors.New("invalid polyhedron JSON")
+)
+
+// jsonVertex is the JSON form of a vertex.
+type jsonVertex struct {
+	ID       int        `json:"id"`
+	Position [3]float64 `json:"position"`
+}
+
+// jsonEdge is the JSON form of an edge. Vertices are indices into the vertex list.
+type jsonEdge struct {
+	ID       int    `json:"id"`
+	Vertices [2]int `json:"vertices"`
+}
+
+// jsonFace is the JSON form of a face. Vertices are indices into the vertex list
+// in boundary order.
+type jsonFace struct {
+	ID       int   `json:"id"`
+	Vertices []int `json:"vertices"`
+}
+
+// jsonSource is the JSON form of one provenance entry: the output element ID and
+// the kind and ID of the input element it came from.
+type jsonSource struct {
+	ID     int    `json:"id"`
+	Kind   string `json:"kind"`
+	Source int    `json:"source"`
+}
+
+// jsonProvenanceStep is the JSON form of one provenance step.
+type jsonProvenanceStep struct {
+	Operation string       `json:"operation"`
+	Vertices  []jsonSource `json:"vertices"`
+	Edges     []jsonSource `json:"edges"`
+	Faces     []jsonSource `json:"faces"`
+}
+
+// jsonPolyhedron is the JSON document for a polyhedron.
+type jsonPolyhedron struct {
+	Version    int                  `json:"version"`
+	Name       string               `json:"name"`
+	NextID     int64                `json:"nextId,omitempty"`
+	Vertices   []jsonVertex         `json:"vertices"`
+	Edges      []jsonEdge           `json:"edges,omitempty"`
+	Faces      []jsonFace           `json:"faces"`
+	Provenance []jsonProvenanceStep `json:"provenance,omitempty"` // Newest step first
+}
+
+// MarshalJSON implements json.Marshaler. Vertices are listed with their IDs and
+// positions, and edges and faces refer to vertices by index into that list.
+// Element IDs are included so a round trip preserves them exactly.
+func (p *Polyhedron) MarshalJSON() ([]byte, error) {
+	rec := p.snapshot()
+
+	doc := jsonPolyhedron{
+		Version:    jsonFormatVersion,
+		Name:       rec.name,
+		NextID:     rec.nextID,
+		Vertices:   make([]jsonVertex, len(rec.vertices)),
+		Edges:      make([]jsonEdge, len(rec.edges)),
+		Faces:      make([]jsonFace, len(rec.faces)),
+		Provenance: provenanceToJSON(rec.provenance),
+	}
+
+	index := make(map[int]int, len(rec.vertices))
+
+	for i, vr := range rec.vertices {
+		index[vr.id] = i
+		doc.Vertices[i] = jsonVertex{ID: vr.id, Position: [3]float64{vr.position.X, vr.position.Y, vr.position.Z}}
+	}
+
+	for i, er := range rec.edges {
+		doc.Edges[i] = jsonEdge{ID: er.id, Vertices: [2]int{index[er.v1], index[er.v2]}}
+	}
+
+	for i, fr := range rec.faces {
+		indices := make([]int, len(fr.vertices))
+
+		for j, id := range fr.vertices {
+			indices[j] = index[id]
+		}
+
+		doc.Faces[i] = jsonFace{ID: fr.id, Vertices: indices}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encoding polyhedron JSON: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of p.
+// The edge list is optional; edges missing from it are rebuilt from the faces.
+func (p *Polyhedron) UnmarshalJSON(data []byte) error {
+	var doc jsonPolyhedron
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+
+	if doc.Version != jsonFormatVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedEncoding, doc.Version)
+	}
+
+	rec, err := doc.records()
+	if err != nil {
+		return err
+	}
+
+	decoded, err := rec.restore()
+	if err != nil {
+		return err
+	}
+
+	p.replaceWith(decoded)
+
+	return nil
+}
+
+// records converts the JSON document to ID-based records, checking every index.
+func (doc jsonPolyhedron) records() (polyhedronRecord, error) {
+	rec := polyhedronRecord{
+		name:       doc.Name,
+		nextID:     doc.NextID,
+		vertices:   make([]vertexRecord, len(doc.Vertices)),
+		edges:      make([]edgeRecord, len(doc.Edges)),
+		faces:      make([]faceRecord, len(doc.Faces)),
+		provenance: nil, // Set once the elements have been checked
+	}
+
+	for i, jv := range doc.Vertices {
+		rec.vertices[i] = vertexRecord{id: jv.ID, position: Vector3{X: jv.Position[0], Y: jv.Position[1], Z: jv.Position[2]}}
+	}
+
+	vertexID := func(index int) (int, bool) {
+		if index < 0 || index >= len(doc.Vertices) {
+			return 0, false
+		}
+
+		return doc.Vertices[index].ID, true
+	}
+
+	for i, je := range doc.Edges {
+		v1, ok1 := vertexID(je.Vertices[0])
+		v2, ok2 := vertexID(je.Vertices[1])
+
+		if !ok1 || !ok2 {
+			return rec, fmt.Errorf("%w: edge %d has a vertex index out of range", ErrInvalidJSON, je.ID)
+		}
+
+		rec.edges[i] = edgeRecord{id: je.ID, v1: v1, v2: v2}
+	}
+
+	for i, jf := range doc.Faces {
+		ids := make([]int, len(jf.Vertices))
+
+		for j, index := range jf.Vertices {
+			id, ok := vertexID(index)
+			if !ok {
+				return rec, fmt.Errorf("%w: face %d has vertex index %d out of range", ErrInvalidJSON, jf.ID, index)
+			}
+
+			ids[j] = id
+		}
+
+		rec.faces[i] = faceRecord{id: jf.ID, vertices: ids}
+	}
+
+	provenance, err := provenanceFromJSON(doc.Provenance)
+	rec.provenance = provenance
+
+	return rec, err
+}
+
+// provenanceToJSON converts a provenance chain to its JSON steps, newest first.
+func provenanceToJSON(pr *Provenance) []jsonProvenanceStep {
+	steps := make([]jsonProvenanceStep, 0, pr.Depth())
+
+	for step := pr; step != nil; step = step.Parent {
+		js := jsonProvenanceStep{Operation: step.Operation, Vertices: nil, Edges: nil, Faces: nil}
+		lists := []*[]jsonSource{&js.Vertices, &js.Edges, &js.Faces}
+
+		for i, kind := range elementKinds() {
+			sources := step.elements(kind)
+			list := make([]jsonSource, 0, len(sources))
+
+			for _, id := range slices.Sorted(maps.Keys(sources)) {
+				list = append(list, jsonSource{ID: id, Kind: sources[id].Kind.String(), Source: sources[id].ID})
+			}
+
+			*lists[i] = list
+		}
+
+		steps = append(steps, js)
+	}
+
+	return steps
+}
+
+// provenanceFromJSON rebuilds a provenance chain from its JSON steps.
+func provenanceFromJSON(steps []jsonProvenanceStep) (*Provenance, error) {
+	var pr *Provenance
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		pr = newProvenance(steps[i].Operation, pr)
+		lists := [][]jsonSource{steps[i].Vertices, steps[i].Edges, steps[i].Faces}
+
+		for j, kind := range elementKinds() {
+			sources := pr.elements(kind)
+
+			for _, js := range lists[j] {
+				sourceKind, ok := parseElementKind(js.Kind)
+				if !ok {
+					return nil, fmt.Errorf("%w: %w %q in provenance", ErrInvalidJSON, ErrInvalidElementKind, js.Kind)
+				}
+
+				sources[js.ID] = Source{Kind: sourceKind, ID: js.Source}
+			}
+		}
+	}
+
+	return pr, nil
+}
+
+// parseElementKind returns the element kind named by ElementKind.String.
+func parseElementKind(name string) (ElementKind, bool) {
+	for _, kind := range elementKinds() {
+		if kind.String() == name {
+			return kind, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,134 @@
+package conway_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"C", "aO", "ktD", "gI"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			original := conway.MustParse(notation)
+
+			data, err := json.Marshal(original)
+			require.NoError(t, err)
+
+			var decoded conway.Polyhedron
+			require.NoError(t, json.Unmarshal(data, &decoded))
+
+			assertSamePolyhedron(t, original, &decoded)
+			require.NoError(t, decoded.ValidateTopology())
+		})
+	}
+}
+
+func TestJSONDocument(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(conway.Tetrahedron())
+	require.NoError(t, err)
+
+	var doc struct {
+		Version  int    `json:"version"`
+		Name     string `json:"name"`
+		Vertices []struct {
+			ID       int        `json:"id"`
+			Position [3]float64 `json:"position"`
+		} `json:"vertices"`
+		Faces []struct {
+			Vertices []int `json:"vertices"`
+		} `json:"faces"`
+	}
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, 1, doc.Version)
+	assert.Equal(t, "Tetrahedron", doc.Name)
+	assert.Len(t, doc.Vertices, 4)
+	require.Len(t, doc.Faces, 4)
+
+	for _, face := range doc.Faces {
+		for _, index := range face.Vertices {
+			assert.True(t, index >= 0 && index < 4, "face index %d is not a vertex index", index)
+		}
+	}
+}
+
+func TestUnmarshalJSONWithoutEdges(t *testing.T) {
+	t.Parallel()
+
+	doc := `{
+		"version": 1,
+		"name": "pyramid",
+		"vertices": [
+			{"id": 1, "position": [0, 0, 1]},
+			{"id": 2, "position": [1, 0, 0]},
+			{"id": 3, "position": [0, 1, 0]},
+			{"id": 4, "position": [-1, -1, 0]}
+		],
+		"faces": [
+			{"id": 10, "vertices": [0, 1, 2]},
+			{"id": 11, "vertices": [0, 2, 3]},
+			{"id": 12, "vertices": [0, 3, 1]},
+			{"id": 13, "vertices": [1, 3, 2]}
+		]
+	}`
+
+	var p conway.Polyhedron
+	require.NoError(t, json.Unmarshal([]byte(doc), &p))
+
+	assert.Equal(t, "pyramid: V=4, E=6, F=4, χ=2", p.Stats())
+	assert.Equal(t, 3, p.Faces[13].Vertices[2].ID)
+	require.NoError(t, p.ValidateTopology())
+
+	for id := range p.Edges {
+		assert.Greater(t, id, 13, "rebuilt edges must not reuse stored IDs")
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+		err  error
+	}{
+		{"Malformed", `{"version": 1,`, conway.ErrInvalidJSON},
+		{"Version", `{"version": 7, "vertices": [], "faces": []}`, conway.ErrUnsupportedEncoding},
+		{
+			"FaceIndex",
+			`{"version": 1, "vertices": [{"id": 1, "position": [0,0,0]}], "faces": [{"id": 2, "vertices": [0, 5, 0]}]}`,
+			conway.ErrInvalidJSON,
+		},
+		{
+			"EdgeIndex",
+			`{"version": 1, "vertices": [{"id": 1, "position": [0,0,0]}], "edges": [{"id": 2, "vertices": [0, -1]}], "faces": []}`,
+			conway.ErrInvalidJSON,
+		},
+		{
+			"DuplicateVertex",
+			`{"version": 1, "vertices": [{"id": 1, "position": [0,0,0]}, {"id": 1, "position": [1,0,0]}], "faces": []}`,
+			conway.ErrInvalidEncoding,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var p conway.Polyhedron
+
+			err := p.UnmarshalJSON([]byte(tc.doc))
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
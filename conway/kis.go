@@ -1,6 +1,15 @@
 package conway
 
-type KisOp struct{}
+const (
+	// kisPyramidHeight is the distance each apex is raised along its face normal.
+	kisPyramidHeight = 0.5
+)
+
+// KisOp raises a pyramid on every face. Workers sets how many goroutines build
+// the output; zero uses one per available CPU.
+type KisOp struct {
+	Workers int
+}
 
 func (k KisOp) Symbol() string {
 	return "k"
@@ -11,41 +20,62 @@ func (k KisOp) Name() string {
 }
 
 func (k KisOp) Apply(p *Polyhedron) *Polyhedron {
-	kis := NewPolyhedron("k" + p.Name)
-
-	vertexMap := make(map[int]*Vertex)
+	vertices := sortedVertices(p)
+	faces := sortedFaces(p)
+	vertexIndex := indexVertices(vertices)
 
-	for _, v := range p.Vertices {
-		newV := kis.AddVertex(v.Position)
+	// Each face of degree n becomes n triangles; offsets locate each face's slots.
+	offsets := make([]int, len(faces)+1)
 
-		vertexMap[v.ID] = newV
+	for i, face := range faces {
+		offsets[i+1] = offsets[i] + len(face.Vertices)
 	}
 
-	pyramidHeight := 0.5
+	b := newMeshBuilder(len(vertices)+len(faces), offsets[len(faces)])
 
-	for _, face := range p.Faces {
-		centroid := face.Centroid()
+	b.trace(k.Symbol(), p, func(a, c int) Source {
+		if a > c {
+			a, c = c, a
+		}
 
-		normal := face.Normal()
+		if c >= len(vertices) {
+			return Source{Kind: FaceElement, ID: faces[c-len(vertices)].ID}
+		}
 
-		apexPos := centroid.Add(normal.Scale(pyramidHeight))
+		if e := edgeBetween(vertices[a], vertices[c]); e != nil {
+			return Source{Kind: EdgeElement, ID: e.ID}
+		}
 
-		apex := kis.AddVertex(apexPos)
+		return Source{Kind: VertexElement, ID: vertices[a].ID}
+	})
 
-		faceVertices := make([]*Vertex, len(face.Vertices))
+	for i, v := range vertices {
+		b.positions[i] = v.Position
+		b.vertexSources[i] = Source{Kind: VertexElement, ID: v.ID}
+	}
 
-		for i, v := range face.Vertices {
-			faceVertices[i] = vertexMap[v.ID]
-		}
+	parallelFor(k.Workers, len(faces), func(start, end int) {
+		for i := start; i < end; i++ {
+			face := faces[i]
+
+			apex := len(vertices) + i
+			b.positions[apex] = face.Centroid().Add(face.Normal().Scale(kisPyramidHeight))
+			b.vertexSources[apex] = Source{Kind: FaceElement, ID: face.ID}
+
+			n := len(face.Vertices)
 
-		for i := 0; i < len(faceVertices); i++ {
-			v1 := faceVertices[i]
+			for j := 0; j < n; j++ {
+				v1 := vertexIndex[face.Vertices[j].ID]
 
-			v2 := faceVertices[(i+1)%len(faceVertices)]
+				v2 := vertexIndex[face.Vertices[(j+1)%n].ID]
 
-			kis.AddFace([]*Vertex{v1, v2, apex})
+				b.faces[offsets[i]+j] = []int{v1, v2, apex}
+				b.faceSources[offsets[i]+j] = Source{Kind: FaceElement, ID: face.ID}
+			}
 		}
-	}
+	})
+
+	kis := b.build("k"+p.Name, k.Workers)
 
 	kis.Normalize()
 
@@ -0,0 +1,263 @@
+package conway
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+const (
+	// tetrahedronVolumeScale divides the triple product of a tetrahedron's edge vectors
+	// to give its volume.
+	tetrahedronVolumeScale = 6
+	// tetrahedronFirstMomentScale divides the triple product times the sum of the vertex
+	// vectors to give a tetrahedron's first moment about its apex.
+	tetrahedronFirstMomentScale = 24
+	// tetrahedronSecondMomentScale divides the triple product in a tetrahedron's second
+	// moment about its apex.
+	tetrahedronSecondMomentScale = 120
+	// jacobiSweeps bounds the rotations sweeps of the eigenvalue solver.
+	jacobiSweeps = 50
+	// jacobiTolerance is the off-diagonal magnitude, relative to the matrix, below which
+	// the eigenvalue solver stops.
+	jacobiTolerance = 1e-15
+)
+
+// MassProperties describes the solid enclosed by a polyhedron with unit density.
+type MassProperties struct {
+	Volume      float64 // Enclosed volume, positive when faces are wound outwards
+	SurfaceArea float64 // Total area of the faces
+	Centroid    Vector3 // Centre of mass of the enclosed solid
+	// Inertia is the inertia tensor about the centre of mass. For a density other than
+	// one, multiply it by the density.
+	Inertia [3][3]float64
+	// PrincipalMoments are the eigenvalues of the inertia tensor, smallest first.
+	PrincipalMoments [3]float64
+	// PrincipalAxes are the unit eigenvectors matching PrincipalMoments. They form a
+	// right-handed frame.
+	PrincipalAxes [3]Vector3
+}
+
+// Volume returns the volume enclosed by the polyhedron, computed with the divergence
+// theorem. It is positive when the faces are wound outwards and meaningful only for
+// closed surfaces.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) Volume() float64 {
+	return p.MassProperties().Volume
+}
+
+// SurfaceArea returns the total area of the polyhedron's faces.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) SurfaceArea() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	area := 0.0
+
+	for _, f := range sortedFaces(p) {
+		area += f.Area()
+	}
+
+	return area
+}
+
+// MassProperties returns the volume, surface area, centre of mass and inertia of the
+// solid the polyhedron encloses, treated as having unit density. Unlike Centroid,
+// which averages the vertices, the centre of mass accounts for how the volume is
+// distributed. The surface should be closed and wound outwards.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) MassProperties() MassProperties {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Integrate over tetrahedra joining each triangle to the vertex centroid, which
+	// keeps the sums well conditioned wherever the polyhedron lies.
+	origin := p.calculateCentroidUnsafe()
+	props := MassProperties{
+		Volume:           0,
+		SurfaceArea:      0,
+		Centroid:         origin,
+		Inertia:          [3][3]float64{},
+		PrincipalMoments: [3]float64{},
+		PrincipalAxes:    [3]Vector3{},
+	}
+
+	first := Vector3{X: 0, Y: 0, Z: 0}
+
+	var second [3][3]float64
+
+	for _, f := range sortedFaces(p) {
+		props.SurfaceArea += f.Area()
+
+		a := f.Vertices[0].Position.Sub(origin)
+
+		for i := 1; i+1 < len(f.Vertices); i++ {
+			b := f.Vertices[i].Position.Sub(origin)
+			c := f.Vertices[i+1].Position.Sub(origin)
+			det := a.Dot(b.Cross(c))
+			sum := a.Add(b).Add(c)
+
+			props.Volume += det / tetrahedronVolumeScale
+			first = first.Add(sum.Scale(det / tetrahedronFirstMomentScale))
+
+			for _, v := range []Vector3{a, b, c, sum} {
+				addOuter(&second, v, det/tetrahedronSecondMomentScale)
+			}
+		}
+	}
+
+	offset := Vector3{X: 0, Y: 0, Z: 0}
+	if props.Volume != 0 {
+		offset = first.Scale(1 / props.Volume)
+	}
+
+	props.Centroid = origin.Add(offset)
+
+	// Move the second moment to the centre of mass, then form the inertia tensor.
+	addOuter(&second, offset, -props.Volume)
+
+	trace := second[0][0] + second[1][1] + second[2][2]
+
+	for i := range 3 {
+		for j := range 3 {
+			props.Inertia[i][j] = -second[i][j]
+		}
+
+		props.Inertia[i][i] += trace
+	}
+
+	props.PrincipalMoments, props.PrincipalAxes = symmetricEigen(props.Inertia)
+
+	return props
+}
+
+// addOuter adds scale times the outer product of v with itself to m.
+func addOuter(m *[3][3]float64, v Vector3, scale float64) {
+	components := [3]float64{v.X, v.Y, v.Z}
+
+	for i := range 3 {
+		for j := range 3 {
+			m[i][j] += scale * components[i] * components[j]
+		}
+	}
+}
+
+// symmetricEigen returns the eigenvalues of a symmetric matrix, smallest first, and
+// their unit eigenvectors as a right-handed frame, using Jacobi rotations. Each
+// eigenvector's largest component is made positive so the result is deterministic.
+func symmetricEigen(m [3][3]float64) ([3]float64, [3]Vector3) {
+	a := m
+	v := [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+	scale := 0.0
+
+	for i := range 3 {
+		for j := range 3 {
+			scale = math.Max(scale, math.Abs(m[i][j]))
+		}
+	}
+
+	for range jacobiSweeps {
+		if math.Abs(a[0][1])+math.Abs(a[0][2])+math.Abs(a[1][2]) <= jacobiTolerance*scale {
+			break
+		}
+
+		for p := range 2 {
+			for q := p + 1; q < 3; q++ {
+				jacobiRotate(&a, &v, p, q)
+			}
+		}
+	}
+
+	order := []int{0, 1, 2}
+	slices.SortStableFunc(order, func(i, j int) int { return cmp.Compare(a[i][i], a[j][j]) })
+
+	var values [3]float64
+
+	var vectors [3]Vector3
+
+	for k, i := range order {
+		values[k] = a[i][i]
+		vectors[k] = Vector3{X: v[0][i], Y: v[1][i], Z: v[2][i]}
+
+		if largest := dominantComponent(vectors[k]); largest < 0 {
+			vectors[k] = vectors[k].Scale(-1)
+		}
+	}
+
+	vectors[2] = vectors[0].Cross(vectors[1])
+
+	return values, vectors
+}
+
+// jacobiRotate applies the rotation that zeroes a[p][q], accumulating it in v.
+func jacobiRotate(a, v *[3][3]float64, p, q int) {
+	if a[p][q] == 0 {
+		return
+	}
+
+	theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+	t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+
+	if theta < 0 {
+		t = -t
+	}
+
+	c := 1 / math.Sqrt(t*t+1)
+	s := t * c
+
+	for k := range 3 {
+		akp, akq := a[k][p], a[k][q]
+		a[k][p] = c*akp - s*akq
+		a[k][q] = s*akp + c*akq
+	}
+
+	for k := range 3 {
+		apk, aqk := a[p][k], a[q][k]
+		a[p][k] = c*apk - s*aqk
+		a[q][k] = s*apk + c*aqk
+	}
+
+	for k := range 3 {
+		vkp, vkq := v[k][p], v[k][q]
+		v[k][p] = c*vkp - s*vkq
+		v[k][q] = s*vkp + c*vkq
+	}
+}
+
+// dominantComponent returns the component of v with the largest magnitude.
+func dominantComponent(v Vector3) float64 {
+	largest := v.X
+
+	for _, c := range []float64{v.Y, v.Z} {
+		if math.Abs(c) > math.Abs(largest) {
+			largest = c
+		}
+	}
+
+	return largest
+}
+
+// AlignPrincipalAxes moves the polyhedron's centre of mass to the origin and rotates
+// it so its principal axes lie along X, Y and Z, with the smallest moment of inertia
+// about X: an elongated shape ends up lying along X. All cached properties are
+// invalidated.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) AlignPrincipalAxes() {
+	props := p.MassProperties()
+	axes := props.PrincipalAxes
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, v := range p.Vertices {
+		d := v.Position.Sub(props.Centroid)
+		v.Position = Vector3{X: d.Dot(axes[0]), Y: d.Dot(axes[1]), Z: d.Dot(axes[2])}
+	}
+
+	p.invalidateCache()
+
+	for _, f := range p.Faces {
+		f.invalidateFaceCache()
+	}
+}
@@ -0,0 +1,160 @@
+package conway_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeAndSurfaceArea(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		poly   *conway.Polyhedron
+		volume float64 // Volume of unit edge length
+		area   float64 // Surface area of unit edge length
+	}{
+		{"Tetrahedron", conway.Tetrahedron(), 1 / (6 * math.Sqrt2), math.Sqrt(3)},
+		{"Cube", conway.Cube(), 1, 6},
+		{"Octahedron", conway.Octahedron(), math.Sqrt2 / 3, 2 * math.Sqrt(3)},
+		{"Dodecahedron", conway.Dodecahedron(), (15 + 7*math.Sqrt(5)) / 4, 3 * math.Sqrt(25+10*math.Sqrt(5))},
+		{"Icosahedron", conway.Icosahedron(), 5 * (3 + math.Sqrt(5)) / 12, 5 * math.Sqrt(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			edge := tt.poly.Edges[lowestEdgeID(tt.poly)].Length()
+
+			assert.InDelta(t, tt.volume*edge*edge*edge, tt.poly.Volume(), 1e-9)
+			assert.InDelta(t, tt.area*edge*edge, tt.poly.SurfaceArea(), 1e-9)
+		})
+	}
+}
+
+func TestMassPropertiesCentroid(t *testing.T) {
+	t.Parallel()
+
+	// A square pyramid's centre of mass is a quarter of the way up, while the average
+	// of its vertices is a fifth of the way up.
+	p := conway.NewPolyhedron("pyramid")
+	corners := []*conway.Vertex{
+		p.AddVertex(conway.Vector3{X: -1, Y: -1, Z: 0}),
+		p.AddVertex(conway.Vector3{X: 1, Y: -1, Z: 0}),
+		p.AddVertex(conway.Vector3{X: 1, Y: 1, Z: 0}),
+		p.AddVertex(conway.Vector3{X: -1, Y: 1, Z: 0}),
+	}
+	apex := p.AddVertex(conway.Vector3{X: 0, Y: 0, Z: 1})
+
+	p.AddFace(corners)
+
+	for i := range corners {
+		p.AddFace([]*conway.Vertex{corners[i], corners[(i+1)%4], apex})
+	}
+
+	props := p.MassProperties()
+
+	assert.InDelta(t, 4.0/3, props.Volume, 1e-12)
+	assert.InDelta(t, 0.2, p.Centroid().Z, 1e-12)
+	assert.InDelta(t, 0.25, props.Centroid.Z, 1e-12)
+	assert.InDelta(t, 0, props.Centroid.X, 1e-12)
+	assert.InDelta(t, 4+4*math.Sqrt2, props.SurfaceArea, 1e-12)
+}
+
+func TestMassPropertiesInertia(t *testing.T) {
+	t.Parallel()
+
+	// A solid cube of side s and mass m has moment m s² / 6 about every axis.
+	p := conway.Cube()
+	side := p.Edges[lowestEdgeID(p)].Length()
+	props := p.MassProperties()
+	moment := props.Volume * side * side / 6
+
+	for i := range 3 {
+		for j := range 3 {
+			expected := 0.0
+			if i == j {
+				expected = moment
+			}
+
+			assert.InDelta(t, expected, props.Inertia[i][j], 1e-12)
+		}
+
+		assert.InDelta(t, moment, props.PrincipalMoments[i], 1e-12)
+	}
+}
+
+func TestAlignPrincipalAxes(t *testing.T) {
+	t.Parallel()
+
+	// Stretch a cube into a 3 × 2 × 1 box, tilt it, then align it again.
+	p := conway.Cube()
+	yaw, pitch := 0.7, -0.4
+
+	for _, v := range p.Vertices {
+		pos := conway.Vector3{X: 3 * v.Position.X, Y: 2 * v.Position.Y, Z: v.Position.Z}
+		pos = conway.Vector3{X: pos.X*math.Cos(yaw) - pos.Y*math.Sin(yaw), Y: pos.X*math.Sin(yaw) + pos.Y*math.Cos(yaw), Z: pos.Z}
+		pos = conway.Vector3{X: pos.X, Y: pos.Y*math.Cos(pitch) - pos.Z*math.Sin(pitch), Z: pos.Y*math.Sin(pitch) + pos.Z*math.Cos(pitch)}
+		v.Position = pos.Add(conway.Vector3{X: 5, Y: -2, Z: 1})
+	}
+
+	before := p.MassProperties()
+
+	assert.Less(t, before.PrincipalMoments[0], before.PrincipalMoments[1])
+	assert.Less(t, before.PrincipalMoments[1], before.PrincipalMoments[2])
+	assert.InDelta(t, 1, before.PrincipalAxes[0].Cross(before.PrincipalAxes[1]).Dot(before.PrincipalAxes[2]), 1e-9, "right-handed")
+
+	p.AlignPrincipalAxes()
+
+	after := p.MassProperties()
+
+	assert.InDelta(t, before.Volume, after.Volume, 1e-9)
+	assert.InDelta(t, 0, after.Centroid.Length(), 1e-9)
+
+	for i := range 3 {
+		assert.InDelta(t, before.PrincipalMoments[i], after.Inertia[i][i], 1e-9)
+	}
+
+	assert.InDelta(t, 0, after.Inertia[0][1], 1e-9)
+	assert.InDelta(t, 0, after.Inertia[0][2], 1e-9)
+	assert.InDelta(t, 0, after.Inertia[1][2], 1e-9)
+
+	// The longest side lies along X and the shortest along Z.
+	var extent conway.Vector3
+
+	for _, v := range p.Vertices {
+		extent = conway.Vector3{X: math.Max(extent.X, math.Abs(v.Position.X)), Y: math.Max(extent.Y, math.Abs(v.Position.Y)), Z: math.Max(extent.Z, math.Abs(v.Position.Z))}
+	}
+
+	assert.InDelta(t, 1.5, extent.X/extent.Y, 1e-9)
+	assert.InDelta(t, 2, extent.Y/extent.Z, 1e-9)
+}
+
+func TestParseWithPrincipalAxes(t *testing.T) {
+	t.Parallel()
+
+	p, err := conway.Parse("tkC", conway.WithPrincipalAxes())
+	require.NoError(t, err)
+
+	props := p.MassProperties()
+	assert.InDelta(t, 0, props.Centroid.Length(), 1e-9)
+
+	e := conway.NewEvaluator(0, conway.WithPrincipalAxes())
+	q, err := e.Evaluate("tkC")
+	require.NoError(t, err)
+	assert.InDelta(t, 0, q.MassProperties().Centroid.Length(), 1e-9)
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	plain, err := cache.Key("tkC")
+	require.NoError(t, err)
+	aligned, err := cache.Key("tkC", conway.WithPrincipalAxes())
+	require.NoError(t, err)
+	assert.NotEqual(t, plain, aligned, "aligned results are cached separately")
+}
@@ -0,0 +1,823 @@
+package conway
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// unfoldDirections is the number of steepest-edge cut directions tried.
+	unfoldDirections = 64
+	// unfoldRoots is the number of root faces tried for breadth-first unfoldings.
+	unfoldRoots = 16
+	// netTolerance is the overlap tolerance as a fraction of the mean edge length.
+	netTolerance = 1e-7
+	// tabHeight is the height of a glue tab as a fraction of its edge's length.
+	tabHeight = 0.2
+	// tabShrinkSteps is how many times a tab's height is halved trying to avoid overlaps.
+	tabShrinkSteps = 3
+	// netLabelSize is the label font size as a fraction of the mean edge length.
+	netLabelSize = 0.12
+	// netLabelInset places a mate-edge label this far inside its face, as a fraction of the edge length.
+	netLabelInset = 0.12
+	// netFoldDash is the dash length of fold lines, in stroke widths.
+	netFoldDash = 4
+	// tabFillGray is the grey level of glue tabs.
+	tabFillGray = 0.9
+	// netPieceGap is the space left between the pieces of a net, as a multiple of the mean edge length.
+	netPieceGap = 1
+	// goldenAngle spreads the cut directions evenly over the sphere.
+	goldenAngle = 2.399963229728653
+)
+
+// Net is a polyhedron unfolded flat for paper-craft: every face laid out in the plane,
+// joined along fold lines, with numbered glue tabs on the edges that are cut. Lengths
+// are in the polyhedron's own units.
+type Net struct {
+	Faces map[int][]Point2 // Face outlines by face ID, in the face's vertex order
+	Folds [][2]Point2      // Fold lines: edges kept between faces, and tab hinges
+	Cuts  [][2]Point2      // Cut lines: the outline of the net including its tabs
+	Tabs  []GlueTab        // One tab per cut edge, numbered from 1
+
+	// Pieces lists the face IDs of each separately cut piece, in ID order. Most
+	// polyhedra unfold into a single piece.
+	Pieces [][]int
+
+	colors map[int]Color // Face colours from ColorAttribute at unfold time
+}
+
+// GlueTab is a flap along one side of a cut edge that is glued under the matching
+// edge of another face. Both are labelled with the tab's number.
+type GlueTab struct {
+	Number   int       // Label shared by the tab and its matching edge
+	Edge     int       // ID of the cut edge
+	Face     int       // ID of the face the tab is attached to
+	Mate     int       // ID of the face the tab is glued to
+	Outline  []Point2  // Tab polygon, starting and ending at the edge's endpoints
+	MateEdge [2]Point2 // Position of the matching edge on the mate face
+}
+
+// Unfold cuts the polyhedron along a spanning tree of its edges and lays the faces out
+// flat without overlaps. It tries steepest-edge unfoldings, which cut each vertex
+// along its edge pointing most nearly in a chosen direction, for a spread of
+// directions, then breadth-first unfoldings from several root faces, returning the
+// first net whose faces do not overlap. Convex polyhedra almost always unfold on the
+// first tries.
+//
+// If every single-piece unfolding overlaps, as can happen for non-convex polyhedra,
+// the faces are attached greedily, each to the first neighbour it fits beside, and
+// faces that fit nowhere start new pieces laid out side by side. Glue tabs are placed
+// on whichever side of each cut avoids overlaps where possible.
+func Unfold(p *Polyhedron) (*Net, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if euler := len(p.Vertices) - len(p.Edges) + len(p.Faces); euler != sphereEulerCharacteristic {
+		return nil, fmt.Errorf("%w: Euler characteristic %d", ErrNotSpherical, euler)
+	}
+
+	for _, e := range p.Edges {
+		if len(e.Faces) != 2 {
+			return nil, fmt.Errorf("%w: edge %d has %d faces", ErrNotSpherical, e.ID, len(e.Faces))
+		}
+	}
+
+	u := newUnfolder(p)
+
+	for i := range unfoldDirections {
+		if net := u.tryFolds(u.steepestEdgeFolds(fibonacciDirection(i, unfoldDirections))); net != nil {
+			return net, nil
+		}
+	}
+
+	for _, root := range u.faces[:min(unfoldRoots, len(u.faces))] {
+		if net := u.tryFolds(u.breadthFirstFolds(root)); net != nil {
+			return net, nil
+		}
+	}
+
+	return u.greedy(), nil
+}
+
+// unfolder holds the geometry shared by every unfolding attempt.
+type unfolder struct {
+	p         *Polyhedron
+	center    Vector3
+	faces     []*Face
+	edges     []*Edge
+	meanEdge  float64
+	tolerance float64
+}
+
+func newUnfolder(p *Polyhedron) *unfolder {
+	edges := sortedEdges(p)
+	total := 0.0
+
+	for _, e := range edges {
+		total += e.Length()
+	}
+
+	mean := total / float64(max(len(edges), 1))
+
+	return &unfolder{
+		p:         p,
+		center:    p.calculateCentroidUnsafe(),
+		faces:     sortedFaces(p),
+		edges:     edges,
+		meanEdge:  mean,
+		tolerance: mean * netTolerance,
+	}
+}
+
+// fibonacciDirection returns the i-th of n directions spread evenly over the sphere.
+func fibonacciDirection(i, n int) Vector3 {
+	z := 1 - (2*float64(i)+1)/float64(n)
+	r := math.Sqrt(1 - z*z)
+	sin, cos := math.Sincos(goldenAngle * float64(i))
+
+	return Vector3{X: r * cos, Y: r * sin, Z: z}
+}
+
+// steepestEdgeFolds cuts, at every vertex except the highest in the given direction,
+// the edge that rises most steeply, and folds along the rest. For convex polyhedra the
+// cut edges always form a spanning tree, so the folds do too in the dual.
+func (u *unfolder) steepestEdgeFolds(direction Vector3) map[int]bool {
+	cuts := make(map[int]bool)
+	vertices := sortedVertices(u.p)
+	top := vertices[0]
+
+	for _, v := range vertices {
+		if v.Position.Dot(direction) > top.Position.Dot(direction) {
+			top = v
+		}
+	}
+
+	for _, v := range vertices {
+		if v == top {
+			continue
+		}
+
+		var steepest *Edge
+
+		best := math.Inf(-1)
+
+		for _, id := range slices.Sorted(maps.Keys(v.Edges)) {
+			e := v.Edges[id]
+			slope := e.OtherVertex(v).Position.Sub(v.Position).Normalize().Dot(direction)
+
+			if slope > best {
+				best, steepest = slope, e
+			}
+		}
+
+		if steepest != nil {
+			cuts[steepest.ID] = true
+		}
+	}
+
+	folds := make(map[int]bool, len(u.edges)-len(cuts))
+
+	for _, e := range u.edges {
+		if !cuts[e.ID] {
+			folds[e.ID] = true
+		}
+	}
+
+	return folds
+}
+
+// breadthFirstFolds folds along the edges of a breadth-first tree of faces from root.
+func (u *unfolder) breadthFirstFolds(root *Face) map[int]bool {
+	folds := make(map[int]bool)
+	seen := map[int]bool{root.ID: true}
+	queue := []*Face{root}
+
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+
+		for _, e := range f.Edges {
+			next := otherFace(e, f)
+			if next == nil || seen[next.ID] {
+				continue
+			}
+
+			seen[next.ID] = true
+			folds[e.ID] = true
+			queue = append(queue, next)
+		}
+	}
+
+	return folds
+}
+
+// otherFace returns the face across an edge from f, or nil.
+func otherFace(e *Edge, f *Face) *Face {
+	for _, g := range e.Faces {
+		if g.ID != f.ID {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// tryFolds lays the faces out along the fold edges, returning nil if the folds do not
+// form a spanning tree of the faces or the faces overlap.
+func (u *unfolder) tryFolds(folds map[int]bool) *Net {
+	if len(folds) != len(u.faces)-1 {
+		return nil
+	}
+
+	layout := u.layout(folds)
+	if len(layout) != len(u.faces) {
+		return nil
+	}
+
+	polygons := make([][]Point2, len(u.faces))
+
+	for i, f := range u.faces {
+		polygons[i] = layout[f.ID]
+	}
+
+	if u.anyOverlap(polygons) {
+		return nil
+	}
+
+	ids := make([]int, len(u.faces))
+
+	for i, f := range u.faces {
+		ids[i] = f.ID
+	}
+
+	return u.buildNet(layout, folds, [][]int{ids})
+}
+
+// greedy unfolds the faces in pieces, attaching each face in breadth-first order to
+// the first placed neighbour it does not overlap, then arranges the pieces in rows.
+func (u *unfolder) greedy() *Net {
+	layout := make(map[int][]Point2, len(u.faces))
+	folds := make(map[int]bool)
+
+	var pieces [][]int
+
+	for _, root := range u.faces {
+		if _, ok := layout[root.ID]; ok {
+			continue
+		}
+
+		piece := []int{root.ID}
+		polygons := [][]Point2{u.flatten(root)}
+		layout[root.ID] = polygons[0]
+
+		for queued := 0; queued < len(piece); queued++ {
+			f := u.p.Faces[piece[queued]]
+
+			for i, e := range f.Edges {
+				next := otherFace(e, f)
+				if next == nil {
+					continue
+				}
+
+				if _, ok := layout[next.ID]; ok {
+					continue
+				}
+
+				a, b := f.Vertices[i], f.Vertices[(i+1)%len(f.Vertices)]
+				outline := u.attach(next, a, b, layout[f.ID][i], layout[f.ID][(i+1)%len(f.Vertices)])
+
+				if u.overlapsAny(outline, polygons) {
+					continue // It may still fit beside another neighbour, or start a piece of its own
+				}
+
+				layout[next.ID] = outline
+				folds[e.ID] = true
+				polygons = append(polygons, outline)
+				piece = append(piece, next.ID)
+			}
+		}
+
+		slices.Sort(piece)
+		pieces = append(pieces, piece)
+	}
+
+	u.arrangePieces(layout, pieces)
+
+	return u.buildNet(layout, folds, pieces)
+}
+
+// arrangePieces translates the pieces of a net into rows so they do not overlap,
+// wrapping rows at about the width of a square holding every piece.
+func (u *unfolder) arrangePieces(layout map[int][]Point2, pieces [][]int) {
+	gap := u.meanEdge * netPieceGap
+	bounds := make([][2]Point2, len(pieces))
+	area := 0.0
+	widest := 0.0
+
+	for i, piece := range pieces {
+		outlines := make([][]Point2, len(piece))
+
+		for j, id := range piece {
+			outlines[j] = layout[id]
+		}
+
+		lo, hi := polygonBounds(slices.Concat(outlines...))
+		bounds[i] = [2]Point2{lo, hi}
+		area += (hi.X - lo.X + gap) * (hi.Y - lo.Y + gap)
+		widest = math.Max(widest, hi.X-lo.X)
+	}
+
+	rowWidth := math.Max(math.Sqrt(area), widest)
+	x, y, rowHeight := 0.0, 0.0, 0.0
+
+	for i, piece := range pieces {
+		lo, hi := bounds[i][0], bounds[i][1]
+
+		if x > 0 && x+hi.X-lo.X > rowWidth {
+			x, y, rowHeight = 0, y+rowHeight+gap, 0
+		}
+
+		dx, dy := x-lo.X, y-lo.Y
+
+		for _, id := range piece {
+			for j, pt := range layout[id] {
+				layout[id][j] = Point2{X: pt.X + dx, Y: pt.Y + dy}
+			}
+		}
+
+		x += hi.X - lo.X + gap
+		rowHeight = math.Max(rowHeight, hi.Y-lo.Y)
+	}
+}
+
+// layout places the first face flat and unfolds its neighbours across the fold edges,
+// breadth first, returning each face's outline by face ID.
+func (u *unfolder) layout(folds map[int]bool) map[int][]Point2 {
+	root := u.faces[0]
+	placed := map[int][]Point2{root.ID: u.flatten(root)}
+	queue := []*Face{root}
+
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+
+		for i, e := range f.Edges {
+			next := otherFace(e, f)
+			if !folds[e.ID] || next == nil {
+				continue
+			}
+
+			if _, ok := placed[next.ID]; ok {
+				continue
+			}
+
+			a, b := f.Vertices[i], f.Vertices[(i+1)%len(f.Vertices)]
+			placed[next.ID] = u.attach(next, a, b, placed[f.ID][i], placed[f.ID][(i+1)%len(f.Vertices)])
+			queue = append(queue, next)
+		}
+	}
+
+	return placed
+}
+
+// flatten returns a face's vertices in 2D, counter-clockwise as seen from outside the
+// polyhedron. The face is developed as a fan of triangles from its first vertex, so
+// every edge keeps its length even if the face is not planar.
+func (u *unfolder) flatten(f *Face) []Point2 {
+	normal := outwardNormal(f, u.center)
+	origin := f.Vertices[0].Position
+	points := make([]Point2, len(f.Vertices))
+	points[1] = Point2{X: f.Vertices[1].Position.Distance(origin), Y: 0}
+
+	for i := 2; i < len(f.Vertices); i++ {
+		prev, v := f.Vertices[i-1].Position, f.Vertices[i].Position
+		d0, d1 := v.Distance(origin), v.Distance(prev)
+
+		base := points[i-1]
+		length := math.Hypot(base.X, base.Y)
+
+		if length == 0 {
+			points[i] = Point2{X: d0, Y: 0} // Degenerate fan triangle
+
+			continue
+		}
+
+		ux, uy := base.X/length, base.Y/length
+
+		// Intersect the circles about the origin and the previous vertex, on the side
+		// given by the turn from the previous vertex to this one about the normal.
+		along := (d0*d0 - d1*d1 + length*length) / (2 * length)
+		across := math.Sqrt(math.Max(d0*d0-along*along, 0))
+
+		if prev.Sub(origin).Cross(v.Sub(origin)).Dot(normal) < 0 {
+			across = -across
+		}
+
+		points[i] = Point2{X: along*ux - across*uy, Y: along*uy + across*ux}
+	}
+
+	return points
+}
+
+// attach flattens a face and moves it rigidly so its copy of the edge between vertices
+// a and b lands on the positions pa and pb in its parent. Both faces are
+// counter-clockwise, so the face unfolds onto the far side of the edge.
+func (u *unfolder) attach(f *Face, a, b *Vertex, pa, pb Point2) []Point2 {
+	local := u.flatten(f)
+	la, lb := local[slices.Index(f.Vertices, a)], local[slices.Index(f.Vertices, b)]
+
+	angle := math.Atan2(pb.Y-pa.Y, pb.X-pa.X) - math.Atan2(lb.Y-la.Y, lb.X-la.X)
+	sin, cos := math.Sincos(angle)
+
+	for i, pt := range local {
+		x, y := pt.X-la.X, pt.Y-la.Y
+		local[i] = Point2{X: pa.X + cos*x - sin*y, Y: pa.Y + sin*x + cos*y}
+	}
+
+	return local
+}
+
+// anyOverlap reports whether any two polygons overlap, sweeping along x so only
+// polygons with overlapping extents are compared.
+func (u *unfolder) anyOverlap(polygons [][]Point2) bool {
+	type extent struct {
+		index    int
+		min, max Point2
+	}
+
+	extents := make([]extent, len(polygons))
+
+	for i, poly := range polygons {
+		lo, hi := polygonBounds(poly)
+		extents[i] = extent{index: i, min: lo, max: hi}
+	}
+
+	slices.SortFunc(extents, func(a, b extent) int { return cmp.Compare(a.min.X, b.min.X) })
+
+	for i, a := range extents {
+		for _, b := range extents[i+1:] {
+			if b.min.X >= a.max.X-u.tolerance {
+				break
+			}
+
+			if b.min.Y >= a.max.Y-u.tolerance || a.min.Y >= b.max.Y-u.tolerance {
+				continue
+			}
+
+			if u.polygonsOverlap(polygons[a.index], polygons[b.index]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// polygonBounds returns the corners of a polygon's bounding box.
+func polygonBounds(poly []Point2) (Point2, Point2) {
+	lo := Point2{X: math.Inf(1), Y: math.Inf(1)}
+	hi := Point2{X: math.Inf(-1), Y: math.Inf(-1)}
+
+	for _, pt := range poly {
+		lo = Point2{X: math.Min(lo.X, pt.X), Y: math.Min(lo.Y, pt.Y)}
+		hi = Point2{X: math.Max(hi.X, pt.X), Y: math.Max(hi.Y, pt.Y)}
+	}
+
+	return lo, hi
+}
+
+// polygonsOverlap reports whether two polygons share interior area. Polygons that only
+// touch along edges or at corners do not overlap.
+func (u *unfolder) polygonsOverlap(a, b []Point2) bool {
+	for i := range a {
+		for j := range b {
+			if u.segmentsCross(a[i], a[(i+1)%len(a)], b[j], b[(j+1)%len(b)]) {
+				return true
+			}
+		}
+	}
+
+	// Without crossing edges, the polygons overlap only if one contains the other.
+	return u.strictlyInside(polygonCentroid(a), b) || u.strictlyInside(polygonCentroid(b), a)
+}
+
+// segmentsCross reports whether segments ab and cd cross at a point interior to both.
+func (u *unfolder) segmentsCross(a, b, c, d Point2) bool {
+	side := func(p, q, r Point2) int {
+		length := math.Hypot(q.X-p.X, q.Y-p.Y)
+		if length == 0 {
+			return 0
+		}
+
+		dist := ((q.X-p.X)*(r.Y-p.Y) - (q.Y-p.Y)*(r.X-p.X)) / length
+
+		switch {
+		case dist > u.tolerance:
+			return 1
+		case dist < -u.tolerance:
+			return -1
+		default:
+			return 0
+		}
+	}
+
+	return side(a, b, c)*side(a, b, d) < 0 && side(c, d, a)*side(c, d, b) < 0
+}
+
+// strictlyInside reports whether a point lies inside a polygon and not on its boundary.
+func (u *unfolder) strictlyInside(pt Point2, poly []Point2) bool {
+	inside := false
+
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+
+		if segmentDistance(Vector3{X: a.X, Y: a.Y, Z: 0}, Vector3{X: b.X, Y: b.Y, Z: 0}, pt.X, pt.Y) <= u.tolerance {
+			return false
+		}
+
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < a.X+(pt.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// polygonCentroid returns the mean of a polygon's vertices.
+func polygonCentroid(poly []Point2) Point2 {
+	var sum Point2
+
+	for _, pt := range poly {
+		sum.X += pt.X
+		sum.Y += pt.Y
+	}
+
+	n := float64(max(len(poly), 1))
+
+	return Point2{X: sum.X / n, Y: sum.Y / n}
+}
+
+// buildNet records fold and cut lines and adds a glue tab to each cut edge.
+func (u *unfolder) buildNet(layout map[int][]Point2, folds map[int]bool, pieces [][]int) *Net {
+	net := &Net{
+		Faces:  layout,
+		Folds:  nil,
+		Cuts:   nil,
+		Tabs:   nil,
+		Pieces: pieces,
+		colors: make(map[int]Color),
+	}
+
+	colorKey := ColorAttribute()
+
+	occupied := make([][]Point2, 0, len(u.faces)+len(u.edges))
+
+	for _, f := range u.faces {
+		if c, ok := colorKey.Get(f); ok {
+			net.colors[f.ID] = c
+		}
+
+		occupied = append(occupied, layout[f.ID])
+	}
+
+	for _, e := range u.edges {
+		sides := u.edgeSides(e, layout)
+
+		if folds[e.ID] {
+			net.Folds = append(net.Folds, sides[0].segment)
+			continue
+		}
+
+		tab, side := u.placeTab(sides, occupied)
+		mate := sides[1-side]
+
+		tab.Number = len(net.Tabs) + 1
+		tab.Edge = e.ID
+		tab.MateEdge = mate.segment
+		net.Tabs = append(net.Tabs, tab)
+		occupied = append(occupied, tab.Outline)
+
+		net.Folds = append(net.Folds, sides[side].segment)
+		net.Cuts = append(net.Cuts, mate.segment)
+
+		for i := range len(tab.Outline) - 1 {
+			net.Cuts = append(net.Cuts, [2]Point2{tab.Outline[i], tab.Outline[i+1]})
+		}
+	}
+
+	return net
+}
+
+// edgeSide is an edge's position on one of its faces in the net.
+type edgeSide struct {
+	face    *Face
+	segment [2]Point2 // Endpoints in the face's counter-clockwise order
+}
+
+// edgeSides returns the positions of an edge on its two faces, lower face ID first.
+func (u *unfolder) edgeSides(e *Edge, layout map[int][]Point2) [2]edgeSide {
+	var sides [2]edgeSide
+
+	for i, f := range sortedFacesOf(e) {
+		n := len(f.Vertices)
+
+		for j := range n {
+			a, b := f.Vertices[j], f.Vertices[(j+1)%n]
+			if (a == e.V1 && b == e.V2) || (a == e.V2 && b == e.V1) {
+				sides[i] = edgeSide{face: f, segment: [2]Point2{layout[f.ID][j], layout[f.ID][(j+1)%n]}}
+			}
+		}
+	}
+
+	return sides
+}
+
+// sortedFacesOf returns the faces of an edge in ID order.
+func sortedFacesOf(e *Edge) []*Face {
+	faces := make([]*Face, 0, len(e.Faces))
+
+	for _, id := range slices.Sorted(maps.Keys(e.Faces)) {
+		faces = append(faces, e.Faces[id])
+	}
+
+	return faces
+}
+
+// placeTab chooses the side of a cut edge for its glue tab, preferring a full-height tab
+// that overlaps nothing, then shorter tabs, and returns the tab and side index.
+func (u *unfolder) placeTab(sides [2]edgeSide, occupied [][]Point2) (GlueTab, int) {
+	height := tabHeight
+
+	for range tabShrinkSteps + 1 {
+		for i, side := range sides {
+			outline := tabOutline(side.segment, height)
+
+			if !u.overlapsAny(outline, occupied) {
+				return newGlueTab(side, sides[1-i], outline), i
+			}
+		}
+
+		height /= 2
+	}
+
+	return newGlueTab(sides[0], sides[1], tabOutline(sides[0].segment, height)), 0
+}
+
+func newGlueTab(side, mate edgeSide, outline []Point2) GlueTab {
+	return GlueTab{Number: 0, Edge: 0, Face: side.face.ID, Mate: mate.face.ID, Outline: outline, MateEdge: mate.segment}
+}
+
+// overlapsAny reports whether a polygon overlaps any of the others.
+func (u *unfolder) overlapsAny(poly []Point2, others [][]Point2) bool {
+	lo, hi := polygonBounds(poly)
+
+	for _, other := range others {
+		olo, ohi := polygonBounds(other)
+
+		if olo.X >= hi.X-u.tolerance || lo.X >= ohi.X-u.tolerance || olo.Y >= hi.Y-u.tolerance || lo.Y >= ohi.Y-u.tolerance {
+			continue
+		}
+
+		if u.polygonsOverlap(poly, other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tabOutline returns a trapezoidal tab on the outer side of an edge of a
+// counter-clockwise face, with sides sloping in at 45 degrees.
+func tabOutline(segment [2]Point2, height float64) []Point2 {
+	a, b := segment[0], segment[1]
+	dx, dy := b.X-a.X, b.Y-a.Y
+
+	// The outside of a counter-clockwise polygon is to the right of each edge.
+	outX, outY := dy*height, -dx*height
+
+	return []Point2{
+		a,
+		{X: a.X + dx*height + outX, Y: a.Y + dy*height + outY},
+		{X: b.X - dx*height + outX, Y: b.Y - dy*height + outY},
+		b,
+	}
+}
+
+// WriteSVG draws the net as an SVG image for printing: faces filled with their
+// ColorAttribute where set, grey glue tabs, solid cut lines, dashed fold lines and
+// tab numbers on each tab and its matching edge. The net is scaled to fit the image
+// size from the options; line colour, width and background come from the style.
+func (n *Net) WriteSVG(w io.Writer, opts ...RenderOption) error {
+	cfg := newRenderConfig(opts)
+
+	polygons := slices.Collect(maps.Values(n.Faces))
+	for _, tab := range n.Tabs {
+		polygons = append(polygons, tab.Outline)
+	}
+
+	lo, hi := polygonBounds(slices.Concat(polygons...))
+	margin := math.Min(float64(cfg.width), float64(cfg.height)) * defaultRenderMargin
+	scale := math.Min((float64(cfg.width)-2*margin)/math.Max(hi.X-lo.X, math.SmallestNonzeroFloat64),
+		(float64(cfg.height)-2*margin)/math.Max(hi.Y-lo.Y, math.SmallestNonzeroFloat64))
+
+	x := func(pt Point2) string { return svgNumber(margin + (pt.X-lo.X)*scale) }
+	y := func(pt Point2) string { return svgNumber(float64(cfg.height) - margin - (pt.Y-lo.Y)*scale) }
+	points := func(poly []Point2) string {
+		parts := make([]string, len(poly))
+
+		for i, pt := range poly {
+			parts[i] = x(pt) + "," + y(pt)
+		}
+
+		return strings.Join(parts, " ")
+	}
+
+	bw := bufio.NewWriter(w)
+	stroke := cfg.style.Stroke
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		cfg.width, cfg.height, cfg.width, cfg.height)
+
+	if bg := cfg.style.Background; bg.A > 0 {
+		fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"%s/>`+"\n", bg.Hex(), svgOpacity("fill-opacity", bg.A))
+	}
+
+	tabFill := RGB(tabFillGray, tabFillGray, tabFillGray)
+	fmt.Fprintf(bw, `<g class="tabs" fill="%s" stroke="none">`+"\n", tabFill.Hex())
+
+	for _, tab := range n.Tabs {
+		fmt.Fprintf(bw, `<polygon points="%s"/>`+"\n", points(tab.Outline))
+	}
+
+	bw.WriteString("</g>\n")
+	bw.WriteString(`<g class="faces" stroke="none">` + "\n")
+
+	for _, id := range slices.Sorted(maps.Keys(n.colors)) {
+		c := n.colors[id]
+		fmt.Fprintf(bw, `<polygon points="%s" fill="%s"%s/>`+"\n", points(n.Faces[id]), c.Hex(), svgOpacity("fill-opacity", c.A))
+	}
+
+	bw.WriteString("</g>\n")
+
+	lines := func(class string, segments [][2]Point2, extra string) {
+		fmt.Fprintf(bw, `<g class="%s" stroke="%s" stroke-width="%s" stroke-linecap="round"%s%s>`+"\n",
+			class, stroke.Hex(), svgNumber(cfg.style.StrokeWidth), svgOpacity("stroke-opacity", stroke.A), extra)
+
+		for _, s := range segments {
+			fmt.Fprintf(bw, `<line x1="%s" y1="%s" x2="%s" y2="%s"/>`+"\n", x(s[0]), y(s[0]), x(s[1]), y(s[1]))
+		}
+
+		bw.WriteString("</g>\n")
+	}
+
+	lines("cuts", n.Cuts, "")
+	lines("folds", n.Folds, fmt.Sprintf(` stroke-dasharray="%s"`, svgNumber(cfg.style.StrokeWidth*netFoldDash)))
+
+	meanEdge := 0.0
+
+	for _, tab := range n.Tabs {
+		meanEdge += pointDistance(tab.MateEdge[0], tab.MateEdge[1]) / float64(len(n.Tabs))
+	}
+
+	fmt.Fprintf(bw, `<g class="labels" fill="%s" font-family="sans-serif" font-size="%s" text-anchor="middle" dominant-baseline="central">`+"\n",
+		stroke.Hex(), svgNumber(meanEdge*netLabelSize*scale))
+
+	for _, tab := range n.Tabs {
+		label := strconv.Itoa(tab.Number)
+		at := polygonCentroid(tab.Outline)
+		fmt.Fprintf(bw, `<text x="%s" y="%s">%s</text>`+"\n", x(at), y(at), label)
+
+		at = edgeLabelPoint(tab.MateEdge)
+		fmt.Fprintf(bw, `<text x="%s" y="%s">%s</text>`+"\n", x(at), y(at), label)
+	}
+
+	bw.WriteString("</g>\n</svg>\n")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing SVG: %w", err)
+	}
+
+	return nil
+}
+
+// edgeLabelPoint returns a point just inside a counter-clockwise face next to the
+// middle of one of its edges.
+func edgeLabelPoint(segment [2]Point2) Point2 {
+	a, b := segment[0], segment[1]
+	dx, dy := b.X-a.X, b.Y-a.Y
+
+	// The inside of a counter-clockwise polygon is to the left of each edge.
+	return Point2{X: (a.X+b.X)/2 - dy*netLabelInset, Y: (a.Y+b.Y)/2 + dx*netLabelInset}
+}
+
+// pointDistance returns the distance between two points.
+func pointDistance(a, b Point2) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
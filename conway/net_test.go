@@ -0,0 +1,167 @@
+package conway_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// polygonsOverlap reports whether two convex polygons share interior area, using the
+// separating axis test with a small tolerance so touching polygons do not overlap.
+func polygonsOverlap(a, b []conway.Point2) bool {
+	separated := func(poly, other []conway.Point2) bool {
+		for i, p := range poly {
+			q := poly[(i+1)%len(poly)]
+			nx, ny := q.Y-p.Y, p.X-q.X
+			length := math.Hypot(nx, ny)
+
+			lo, hi := math.Inf(1), math.Inf(-1)
+			for _, pt := range poly {
+				d := (pt.X*nx + pt.Y*ny) / length
+				lo, hi = math.Min(lo, d), math.Max(hi, d)
+			}
+
+			olo, ohi := math.Inf(1), math.Inf(-1)
+			for _, pt := range other {
+				d := (pt.X*nx + pt.Y*ny) / length
+				olo, ohi = math.Min(olo, d), math.Max(ohi, d)
+			}
+
+			if ohi <= lo+1e-6 || hi <= olo+1e-6 {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return !separated(a, b) && !separated(b, a)
+}
+
+func assertValidNet(t *testing.T, p *conway.Polyhedron, net *conway.Net) {
+	t.Helper()
+
+	require.Len(t, net.Faces, len(p.Faces))
+	assert.Len(t, net.Tabs, len(p.Edges)-len(p.Faces)+len(net.Pieces), "one tab per cut edge")
+	assert.Len(t, net.Folds, len(p.Edges), "every edge is a fold between faces or a tab hinge")
+
+	// Faces keep their shape when laid flat.
+	for id, outline := range net.Faces {
+		f := p.Faces[id]
+
+		for i, v := range f.Vertices {
+			w := f.Vertices[(i+1)%len(f.Vertices)]
+			a, b := outline[i], outline[(i+1)%len(outline)]
+			assert.InDelta(t, v.Position.Distance(w.Position), math.Hypot(b.X-a.X, b.Y-a.Y), 1e-9)
+		}
+	}
+
+	outlines := make([][]conway.Point2, 0, len(net.Faces))
+	for _, outline := range net.Faces {
+		outlines = append(outlines, outline)
+	}
+
+	for i := range outlines {
+		for j := range i {
+			assert.False(t, polygonsOverlap(outlines[i], outlines[j]), "faces overlap")
+		}
+	}
+
+	for i, tab := range net.Tabs {
+		assert.Equal(t, i+1, tab.Number)
+		assert.NotEqual(t, tab.Face, tab.Mate)
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "C", "O", "D", "I", "tI", "aD", "eC", "jD"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(notation)
+			net, err := conway.Unfold(p)
+			require.NoError(t, err)
+
+			assert.Len(t, net.Pieces, 1, "convex polyhedra unfold in one piece")
+			assertValidNet(t, p, net)
+		})
+	}
+}
+
+func TestUnfoldNonConvex(t *testing.T) {
+	t.Parallel()
+
+	// Tall kis pyramids make spiky shapes that cannot always be unfolded in one piece.
+	for _, notation := range []string{"kD", "tkC", "ktI"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(notation)
+			net, err := conway.Unfold(p)
+			require.NoError(t, err)
+
+			count := 0
+			for _, piece := range net.Pieces {
+				count += len(piece)
+			}
+
+			assert.Equal(t, len(p.Faces), count, "every face is in exactly one piece")
+			assertValidNet(t, p, net)
+		})
+	}
+}
+
+func TestUnfoldOpen(t *testing.T) {
+	t.Parallel()
+
+	open := conway.Cube()
+	open.RemoveFace(open.Faces[lowestFaceID(open)])
+
+	_, err := conway.Unfold(open)
+	require.ErrorIs(t, err, conway.ErrNotSpherical)
+}
+
+func TestNetWriteSVG(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+	conway.ColorAttribute().Set(p.Faces[lowestFaceID(p)], conway.RGB(0, 0, 1))
+
+	net, err := conway.Unfold(p)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, net.WriteSVG(&buf, conway.WithSize(400, 300)))
+
+	var doc struct {
+		Groups []struct {
+			Class    string     `xml:"class,attr"`
+			Dash     string     `xml:"stroke-dasharray,attr"`
+			Polygons []struct{} `xml:"polygon"`
+			Lines    []struct{} `xml:"line"`
+			Texts    []string   `xml:"text"`
+		} `xml:"g"`
+	}
+
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	require.Len(t, doc.Groups, 5)
+
+	tabs, faces, cuts, folds, labels := doc.Groups[0], doc.Groups[1], doc.Groups[2], doc.Groups[3], doc.Groups[4]
+
+	assert.Len(t, tabs.Polygons, 7, "a cube net has seven cut edges")
+	assert.Len(t, faces.Polygons, 1, "only coloured faces are filled")
+	assert.Len(t, cuts.Lines, 7*4, "each cut is an edge plus three tab sides")
+	assert.Len(t, folds.Lines, 12)
+	assert.NotEmpty(t, folds.Dash, "fold lines are dashed")
+	assert.Len(t, labels.Texts, 14, "each number labels a tab and its matching edge")
+
+	assert.ErrorIs(t, net.WriteSVG(failingWriter{}), errWriteFailed)
+}
@@ -0,0 +1,88 @@
+package conway
+
+import "strings"
+
+// Option configures how a Parser builds polyhedra.
+type Option func(*parserConfig)
+
+// parserConfig holds the settings collected from Options.
+type parserConfig struct {
+	workers       int
+	diskCache     *DiskCache
+	principalAxes bool
+	normalize     bool
+	normalization NormalizationMode
+}
+
+// newParserConfig applies the options on top of the defaults.
+func newParserConfig(opts []Option) parserConfig {
+	cfg := parserConfig{
+		workers:       0,
+		diskCache:     nil,
+		principalAxes: false,
+		normalize:     false,
+		normalization: UnitCircumradius,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithWorkers sets how many goroutines each operation uses to build its output.
+// Zero or negative values use one worker per available CPU, which is the default.
+// A value of 1 runs every operation serially.
+func WithWorkers(n int) Option {
+	return func(cfg *parserConfig) {
+		cfg.workers = n
+	}
+}
+
+// WithDiskCache makes the parser load results from cache when present and store
+// newly generated polyhedra in it. Polyhedra loaded from the cache are identical
+// to freshly generated ones, including element IDs.
+func WithDiskCache(cache *DiskCache) Option {
+	return func(cfg *parserConfig) {
+		cfg.diskCache = cache
+	}
+}
+
+// WithPrincipalAxes makes the parser align every result with AlignPrincipalAxes,
+// centring its mass at the origin with its principal axes along X, Y and Z.
+func WithPrincipalAxes() Option {
+	return func(cfg *parserConfig) {
+		cfg.principalAxes = true
+	}
+}
+
+// WithNormalization makes the parser rescale every result with NormalizeTo, so the
+// measurement selected by mode is one. Without it, results keep the default scale
+// with the furthest vertex at unit distance.
+func WithNormalization(mode NormalizationMode) Option {
+	return func(cfg *parserConfig) {
+		cfg.normalize, cfg.normalization = true, mode
+	}
+}
+
+// fingerprint identifies the settings that change the geometry a parser produces.
+// It is part of the disk cache key. Settings that only affect how the work is done,
+// such as the worker count, are excluded so they share cache entries.
+func (cfg parserConfig) fingerprint() string {
+	var parts []string
+
+	if cfg.principalAxes {
+		parts = append(parts, "principal-axes")
+	}
+
+	if cfg.normalize {
+		parts = append(parts, cfg.normalization.String())
+	}
+
+	if len(parts) == 0 {
+		return "default"
+	}
+
+	return strings.Join(parts, ",")
+}
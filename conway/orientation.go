@@ -0,0 +1,168 @@
+package conway
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNonOrientable is returned by Orient when no choice of face directions traverses
+// every edge once each way, as for a Möbius strip.
+var ErrNonOrientable = errors.New("polyhedron surface is not orientable")
+
+// orientAgainstNeighboursUnsafe orders a new face's vertices to traverse each edge it
+// shares with existing faces opposite to them, so orientation propagates from face
+// to face. The shared edges vote; a face with no neighbours, or a tied vote, is
+// returned unchanged with false. It performs no locking.
+func (p *Polyhedron) orientAgainstNeighboursUnsafe(vertices []*Vertex) ([]*Vertex, bool) {
+	votes := 0
+
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+
+		e := p.edgeLookup.Find(a.ID, b.ID)
+		if e == nil {
+			continue
+		}
+
+		for _, f := range e.Faces {
+			j := FindEdgeIndex(f, e)
+			if j < 0 {
+				continue
+			}
+
+			if f.Vertices[j].ID == a.ID {
+				votes-- // The neighbour already runs from a to b
+			} else {
+				votes++
+			}
+		}
+	}
+
+	switch {
+	case votes > 0:
+		return vertices, true
+	case votes < 0:
+		reversed := make([]*Vertex, len(vertices))
+
+		for i, v := range vertices {
+			reversed[len(vertices)-1-i] = v
+		}
+
+		return reversed, true
+	default:
+		return vertices, false
+	}
+}
+
+// faceIndices returns the faces in ID order as vertex indices into the vertices in ID
+// order, with those vertices' positions.
+func faceIndices(p *Polyhedron) ([]*Face, []Vector3, [][]int) {
+	vertices := sortedVertices(p)
+	index := indexVertices(vertices)
+	positions := make([]Vector3, len(vertices))
+
+	for i, v := range vertices {
+		positions[i] = v.Position
+	}
+
+	faces := sortedFaces(p)
+	boundaries := make([][]int, len(faces))
+
+	for i, f := range faces {
+		boundaries[i] = make([]int, len(f.Vertices))
+
+		for j, v := range f.Vertices {
+			boundaries[i][j] = index[v.ID]
+		}
+	}
+
+	return faces, positions, boundaries
+}
+
+// IsOrientable reports whether the faces can be wound so that every edge is traversed
+// once in each direction. Surfaces with edges shared by more than two faces are
+// checked pairwise.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) IsOrientable() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, positions, boundaries := faceIndices(p)
+	_, orientable := orientFaces(positions, boundaries)
+
+	return orientable
+}
+
+// ValidateOrientability checks that the surface is orientable, as IsOrientable does.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) ValidateOrientability() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return firstError(p.orientabilityIssues())
+}
+
+// orientabilityIssues reports a non-orientable surface.
+func (p *Polyhedron) orientabilityIssues() []ValidationIssue {
+	_, positions, boundaries := faceIndices(p)
+	if _, orientable := orientFaces(positions, boundaries); orientable {
+		return nil
+	}
+
+	return []ValidationIssue{{
+		Check:     checkOrientability,
+		Severity:  SeverityError,
+		Element:   PolyhedronElement,
+		IDs:       nil,
+		Message:   "Surface is not orientable: no winding traverses every edge once in each direction",
+		Value:     0,
+		Tolerance: 0,
+	}}
+}
+
+// Orient rewinds the faces topologically: one face of each connected component keeps
+// its direction and the rest are made to traverse every shared edge opposite to
+// their neighbours. Each closed component is then turned to enclose positive volume,
+// or negative volume if it is a cavity inside another, and each open one to keep
+// most of its faces' directions. It returns the number of
+// faces reversed, and ErrNonOrientable if some edges could not be made consistent.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) Orient() (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	faces, positions, boundaries := faceIndices(p)
+	flipped, orientable := orientFaces(positions, boundaries)
+	count := 0
+
+	for i, flip := range flipped {
+		if !flip {
+			continue
+		}
+
+		f := faces[i]
+		n := len(f.Vertices)
+		vertices := make([]*Vertex, n)
+		edges := make([]*Edge, n)
+
+		// Edge i joins vertices i and i+1; reversed, edge n-2-i joins the same pair.
+		for j := range n {
+			vertices[j] = f.Vertices[n-1-j]
+			edges[j] = f.Edges[(2*n-2-j)%n]
+		}
+
+		f.Vertices, f.Edges = vertices, edges
+		f.invalidateFaceCache()
+		count++
+	}
+
+	if count > 0 {
+		p.invalidateCache()
+	}
+
+	if !orientable {
+		return count, fmt.Errorf("orienting %s: %w", p.Name, ErrNonOrientable)
+	}
+
+	return count, nil
+}
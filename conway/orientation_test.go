@@ -0,0 +1,138 @@
+package conway_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFacePropagatesOrientation(t *testing.T) {
+	t.Parallel()
+
+	// Rebuild kkkO face by face, each next to one already added, reversing every
+	// other face's input. Its non-convex faces defeat the centroid rule, so only
+	// propagation across shared edges winds them consistently.
+	source := conway.MustParse("kkkO")
+	p := conway.NewPolyhedron("rebuilt")
+	vertices := make(map[int]*conway.Vertex, len(source.Vertices))
+
+	for id, v := range source.Vertices {
+		vertices[id] = p.AddVertex(v.Position)
+	}
+
+	start := source.Faces[lowestFaceID(source)]
+	queue := []*conway.Face{start}
+	seen := map[int]bool{start.ID: true}
+
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+
+		boundary := make([]*conway.Vertex, len(f.Vertices))
+		for i, v := range f.Vertices {
+			boundary[i] = vertices[v.ID]
+		}
+
+		if len(seen)%2 == 0 {
+			slices.Reverse(boundary)
+		}
+
+		p.AddFace(boundary)
+
+		for _, e := range f.Edges {
+			for _, g := range e.Faces {
+				if !seen[g.ID] {
+					seen[g.ID] = true
+					queue = append(queue, g)
+				}
+			}
+		}
+	}
+
+	require.Len(t, p.Faces, len(source.Faces))
+	assert.True(t, p.IsOrientable())
+	assert.InDelta(t, source.Volume(), math.Abs(p.Volume()), 1e-9)
+
+	// The faces all agree with the first, which may face either way; Orient only
+	// turns the whole surface outwards if need be.
+	count, err := p.Orient()
+	require.NoError(t, err)
+	assert.Contains(t, []int{0, len(p.Faces)}, count)
+	require.NoError(t, p.ValidateWinding())
+}
+
+func TestOrient(t *testing.T) {
+	t.Parallel()
+
+	p := inconsistentCube(t)
+	require.Error(t, p.ValidateWinding())
+	assert.True(t, p.IsOrientable())
+
+	count, err := p.Orient()
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.NoError(t, p.ValidateWinding())
+	require.NoError(t, p.ValidateTopology())
+	assert.InDelta(t, 8.0, p.Volume(), 1e-9)
+
+	count, err = p.Orient()
+	require.NoError(t, err)
+	assert.Zero(t, count, "an oriented surface is left alone")
+}
+
+func TestOrientKeepsCavity(t *testing.T) {
+	t.Parallel()
+
+	shell, err := conway.Shell(conway.Octahedron(), 0.1)
+	require.NoError(t, err)
+
+	volume := shell.Volume()
+
+	count, err := shell.Orient()
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.InDelta(t, volume, shell.Volume(), 1e-12)
+	require.NoError(t, shell.ValidateWinding())
+}
+
+func TestOrientNonOrientable(t *testing.T) {
+	t.Parallel()
+
+	// A Möbius strip of eight quads, turning half a revolution as it goes round.
+	p := conway.NewPolyhedron("Möbius strip")
+	const n = 8
+
+	var top, bottom []*conway.Vertex
+
+	for i := range n {
+		theta := 2 * math.Pi * float64(i) / n
+		centre := conway.Vector3{X: 2 * math.Cos(theta), Y: 2 * math.Sin(theta), Z: 0}
+		across := conway.Vector3{X: math.Cos(theta/2) * math.Cos(theta), Y: math.Cos(theta/2) * math.Sin(theta), Z: math.Sin(theta / 2)}
+
+		top = append(top, p.AddVertex(centre.Add(across.Scale(0.5))))
+		bottom = append(bottom, p.AddVertex(centre.Sub(across.Scale(0.5))))
+	}
+
+	for i := range n - 1 {
+		p.AddFace([]*conway.Vertex{top[i], bottom[i], bottom[i+1], top[i+1]})
+	}
+
+	p.AddFace([]*conway.Vertex{top[n-1], bottom[n-1], top[0], bottom[0]})
+
+	assert.False(t, p.IsOrientable())
+	require.Error(t, p.ValidateOrientability())
+
+	_, err := p.Orient()
+	require.ErrorIs(t, err, conway.ErrNonOrientable)
+
+	checks := make([]string, 0)
+	for _, issue := range p.Validate().Issues {
+		checks = append(checks, issue.Check)
+	}
+
+	assert.Contains(t, checks, "Orientability")
+}
@@ -0,0 +1,124 @@
+package conway
+
+import (
+	"maps"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+const (
+	// minParallelChunk is the smallest number of items handed to a single worker.
+	// Below this the goroutine overhead outweighs the work being split.
+	minParallelChunk = 32
+)
+
+// resolveWorkers returns the effective worker count for a configured value.
+// Zero or negative values select one worker per available CPU.
+func resolveWorkers(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+
+	return workers
+}
+
+// parallelFor partitions the index range [0, n) into contiguous chunks and
+// calls fn for each chunk on its own goroutine, returning once all chunks are done.
+// Small ranges are processed on the calling goroutine.
+func parallelFor(workers, n int, fn func(start, end int)) {
+	workers = resolveWorkers(workers)
+
+	if maxWorkers := (n + minParallelChunk - 1) / minParallelChunk; workers > maxWorkers {
+		workers = maxWorkers
+	}
+
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			fn(start, end)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// sortedVertices returns the polyhedron's vertices ordered by ID.
+// A stable order lets operations partition work by index and produce deterministic output.
+func sortedVertices(p *Polyhedron) []*Vertex {
+	vertices := make([]*Vertex, 0, len(p.Vertices))
+
+	for _, id := range slices.Sorted(maps.Keys(p.Vertices)) {
+		vertices = append(vertices, p.Vertices[id])
+	}
+
+	return vertices
+}
+
+// sortedEdges returns the polyhedron's edges ordered by ID.
+func sortedEdges(p *Polyhedron) []*Edge {
+	edges := make([]*Edge, 0, len(p.Edges))
+
+	for _, id := range slices.Sorted(maps.Keys(p.Edges)) {
+		edges = append(edges, p.Edges[id])
+	}
+
+	return edges
+}
+
+// sortedFaces returns the polyhedron's faces ordered by ID.
+func sortedFaces(p *Polyhedron) []*Face {
+	faces := make([]*Face, 0, len(p.Faces))
+
+	for _, id := range slices.Sorted(maps.Keys(p.Faces)) {
+		faces = append(faces, p.Faces[id])
+	}
+
+	return faces
+}
+
+// indexVertices maps vertex IDs to their position in the given slice.
+func indexVertices(vertices []*Vertex) map[int]int {
+	index := make(map[int]int, len(vertices))
+
+	for i, v := range vertices {
+		index[v.ID] = i
+	}
+
+	return index
+}
+
+// indexEdges maps edge IDs to their position in the given slice.
+func indexEdges(edges []*Edge) map[int]int {
+	index := make(map[int]int, len(edges))
+
+	for i, e := range edges {
+		index[e.ID] = i
+	}
+
+	return index
+}
+
+// indexFaces maps face IDs to their position in the given slice.
+func indexFaces(faces []*Face) map[int]int {
+	index := make(map[int]int, len(faces))
+
+	for i, f := range faces {
+		index[f.ID] = i
+	}
+
+	return index
+}
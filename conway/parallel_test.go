@@ -0,0 +1,81 @@
+package conway_test
+
+import (
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sortedPositions returns the polyhedron's vertex positions ordered by vertex ID.
+func sortedPositions(p *conway.Polyhedron) []conway.Vector3 {
+	positions := make([]conway.Vector3, 0, len(p.Vertices))
+
+	for id := 0; len(positions) < len(p.Vertices); id++ {
+		if v, ok := p.Vertices[id]; ok {
+			positions = append(positions, v.Position)
+		}
+	}
+
+	return positions
+}
+
+func TestParallelOperationsMatchSerial(t *testing.T) {
+	t.Parallel()
+
+	base := conway.MustParse("tkD")
+
+	operations := []struct {
+		name     string
+		serial   conway.Operation
+		parallel conway.Operation
+	}{
+		{"Dual", conway.DualOp{Workers: 1}, conway.DualOp{Workers: 8}},
+		{"Ambo", conway.AmboOp{Workers: 1}, conway.AmboOp{Workers: 8}},
+		{"Truncate", conway.TruncateOp{Workers: 1}, conway.TruncateOp{Workers: 8}},
+		{"Kis", conway.KisOp{Workers: 1}, conway.KisOp{Workers: 8}},
+		{"Join", conway.JoinOp{Workers: 1}, conway.JoinOp{Workers: 8}},
+		{"Snub", conway.SnubOp{Workers: 1}, conway.SnubOp{Workers: 8}},
+	}
+
+	for _, tc := range operations {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			serial := tc.serial.Apply(base)
+			parallel := tc.parallel.Apply(base)
+
+			assert.Equal(t, serial.Stats(), parallel.Stats())
+			assert.Equal(t, sortedPositions(serial), sortedPositions(parallel))
+			assert.True(t, parallel.IsValid())
+		})
+	}
+}
+
+func TestParallelOperationsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	first := conway.MustParse("ktI")
+	second := conway.MustParse("ktI")
+
+	assert.Equal(t, sortedPositions(first), sortedPositions(second))
+
+	for id, face := range first.Faces {
+		other, ok := second.Faces[id]
+		require.True(t, ok, "face %d missing from second result", id)
+		assert.Equal(t, len(face.Vertices), len(other.Vertices))
+	}
+}
+
+func TestWithWorkers(t *testing.T) {
+	t.Parallel()
+
+	for _, workers := range []int{-1, 0, 1, 3, 16} {
+		p, err := conway.Parse("tkC", conway.WithWorkers(workers))
+		require.NoError(t, err)
+
+		assert.Equal(t, "tkCube: V=72, E=108, F=38, χ=2", p.Stats())
+		assert.True(t, p.IsValid())
+	}
+}
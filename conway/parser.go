@@ -16,58 +16,87 @@ var (
 
 type Parser struct {
 	operations map[string]Operation
+	config     parserConfig
 }
 
-func NewParser() *Parser {
+func NewParser(opts ...Option) *Parser {
+	cfg := newParserConfig(opts)
+
 	parser := &Parser{
 		operations: make(map[string]Operation),
+		config:     cfg,
 	}
 
-	parser.operations["d"] = DualOp{}
-	parser.operations["a"] = AmboOp{}
-	parser.operations["t"] = TruncateOp{}
-	parser.operations["k"] = KisOp{}
-	parser.operations["j"] = JoinOp{}
-	parser.operations["o"] = OrthoOp{}
-	parser.operations["e"] = ExpandOp{}
-	parser.operations["g"] = GyroOp{}
-	parser.operations["s"] = SnubOp{}
+	parser.operations["d"] = DualOp{Workers: cfg.workers}
+	parser.operations["a"] = AmboOp{Workers: cfg.workers}
+	parser.operations["t"] = TruncateOp{Workers: cfg.workers}
+	parser.operations["k"] = KisOp{Workers: cfg.workers}
+	parser.operations["j"] = JoinOp{Workers: cfg.workers}
+	parser.operations["o"] = OrthoOp{Workers: cfg.workers}
+	parser.operations["e"] = ExpandOp{Workers: cfg.workers}
+	parser.operations["g"] = GyroOp{Workers: cfg.workers}
+	parser.operations["s"] = SnubOp{Workers: cfg.workers}
 
 	return parser
 }
 
 func (p *Parser) Parse(notation string) (*Polyhedron, error) {
+	seedSymbol, operations, err := p.parse(notation)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := p.config.diskCache
+	if cache == nil {
+		return p.build(seedSymbol, operations), nil
+	}
+
+	key := cache.key(canonicalNotation(seedSymbol, operations), p.config)
+
+	if cached, ok := cache.Get(key); ok {
+		return cached, nil
+	}
+
+	result := p.build(seedSymbol, operations)
+
+	// A failed write only costs a later recomputation; it is reported in the cache stats.
+	_ = cache.Put(key, result)
+
+	return result, nil
+}
+
+// parse validates the notation and returns its seed symbol and operations.
+func (p *Parser) parse(notation string) (string, []Operation, error) {
 	notation = strings.TrimSpace(notation)
 	if notation == "" {
-		return nil, ErrEmptyNotation
+		return "", nil, ErrEmptyNotation
 	}
 
-	seed, operations, err := p.parseNotation(notation)
+	seedSymbol, operations, err := p.parseNotation(notation)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
-	if seed == nil {
-		return nil, ErrNoSeedPolyhedron
+	if seedSymbol == "" {
+		return "", nil, ErrNoSeedPolyhedron
 	}
 
-	return p.applyOperations(seed, operations), nil
+	return seedSymbol, operations, nil
 }
 
-// parseNotation extracts seed and operations from notation string.
-func (p *Parser) parseNotation(notation string) (*Polyhedron, []Operation, error) {
-	var seed *Polyhedron
+// parseNotation extracts the seed symbol and operations from notation string.
+// The seed symbol is empty if the notation does not name a seed.
+func (p *Parser) parseNotation(notation string) (string, []Operation, error) {
+	seedSymbol := ""
 
 	var operations []Operation
 
 	for i, char := range notation {
 		symbol := string(char)
 
-		if seed == nil {
-			if parsedSeed := GetSeed(symbol); parsedSeed != nil {
-				seed = parsedSeed
-				continue
-			}
+		if seedSymbol == "" && isSeedSymbol(symbol) {
+			seedSymbol = symbol
+			continue
 		}
 
 		if op, exists := p.operations[symbol]; exists {
@@ -75,24 +104,52 @@ func (p *Parser) parseNotation(notation string) (*Polyhedron, []Operation, error
 			continue
 		}
 
-		if seed == nil && i == len(notation)-1 {
-			if lastSeed := GetSeed(symbol); lastSeed != nil {
-				seed = lastSeed
-				continue
-			}
-
-			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownSeedPolyhedron, symbol)
+		if seedSymbol == "" && i == len(notation)-1 {
+			return "", nil, fmt.Errorf("%w: %s", ErrUnknownSeedPolyhedron, symbol)
 		}
 
-		return nil, nil, fmt.Errorf("%w: %s at position %d", ErrUnknownOperation, symbol, i)
+		return "", nil, fmt.Errorf("%w: %s at position %d", ErrUnknownOperation, symbol, i)
+	}
+
+	return seedSymbol, operations, nil
+}
+
+// canonicalNotation returns the normalized notation for the operations applied to a seed.
+func canonicalNotation(seedSymbol string, operations []Operation) string {
+	var sb strings.Builder
+
+	for _, op := range operations {
+		sb.WriteString(op.Symbol())
+	}
+
+	sb.WriteString(seedSymbol)
+
+	return sb.String()
+}
+
+// build generates the polyhedron for a parsed notation.
+func (p *Parser) build(seedSymbol string, operations []Operation) *Polyhedron {
+	result := p.applyOperations(GetSeed(seedSymbol), operations)
+	p.place(result)
+
+	return result
+}
+
+// place applies the configured scale and placement to a finished polyhedron.
+func (p *Parser) place(result *Polyhedron) {
+	if p.config.normalize {
+		result.NormalizeTo(p.config.normalization)
 	}
 
-	return seed, operations, nil
+	if p.config.principalAxes {
+		result.AlignPrincipalAxes()
+	}
 }
 
-// applyOperations applies the operations to the seed polyhedron.
+// applyOperations applies the operations to the seed polyhedron. The seed is used
+// directly rather than cloned, so provenance refers to the IDs of GetSeed's result.
 func (p *Parser) applyOperations(seed *Polyhedron, operations []Operation) *Polyhedron {
-	result := seed.Clone()
+	result := seed
 
 	for i := len(operations) - 1; i >= 0; i-- {
 		result = operations[i].Apply(result)
@@ -126,14 +183,14 @@ func (p *Parser) GetAvailableSeeds() map[string]string {
 	}
 }
 
-func Parse(notation string) (*Polyhedron, error) {
-	parser := NewParser()
+func Parse(notation string, opts ...Option) (*Polyhedron, error) {
+	parser := NewParser(opts...)
 
 	return parser.Parse(notation)
 }
 
-func MustParse(notation string) *Polyhedron {
-	result, err := Parse(notation)
+func MustParse(notation string, opts ...Option) *Polyhedron {
+	result, err := Parse(notation, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,442 @@
+package conway
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Static errors for err113 compliance.
+var (
+	ErrFaceNotFound = errors.New("face not found")
+	ErrNotSpherical = errors.New("polyhedron is not topologically a sphere")
+)
+
+const (
+	// schlegelViewpointFraction places the Schlegel viewpoint this far between the
+	// projected face and the nearest point from which another face becomes visible.
+	schlegelViewpointFraction = 0.5
+	// tutteTolerance is the relative residual at which the Tutte solver stops.
+	tutteTolerance = 1e-12
+	// tutteIterationFactor bounds the solver's iterations per interior vertex.
+	tutteIterationFactor = 4
+	// planarVertexRadius is the radius of vertex dots as a multiple of the stroke width.
+	planarVertexRadius = 1.5
+	// tikzScale is the TikZ picture scale: the layout's unit disk becomes this many centimetres.
+	tikzScale = 3
+	// tikzPrecision is the number of decimals written for TikZ coordinates.
+	tikzPrecision = 4
+	// sphereEulerCharacteristic is V - E + F for a polyhedron with the topology of a sphere.
+	sphereEulerCharacteristic = 2
+)
+
+// Point2 is a point in the plane.
+type Point2 struct {
+	X, Y float64
+}
+
+// PlanarLayout is a drawing of a polyhedron's vertices and edges in the plane, with one
+// face chosen as the outer face and every other face drawn inside it. Coordinates fit
+// the unit disk, with the outer face counter-clockwise.
+type PlanarLayout struct {
+	Positions map[int]Point2 // Vertex positions by vertex ID
+	Edges     [][2]int       // Vertex ID pairs, lower ID first, sorted
+	Faces     map[int][]int  // Vertex ID cycles by face ID, including the outer face
+	OuterFace int            // ID of the face drawn as the unbounded region
+
+	colors map[int]Color // Face colours from ColorAttribute at layout time
+}
+
+// SchlegelDiagram projects the polyhedron onto the plane of one face from a viewpoint
+// just outside it, so that face becomes the outer boundary and the rest of the surface
+// is seen through it. The diagram is free of crossings for convex polyhedra; for other
+// shapes, TutteEmbedding always gives a crossing-free drawing.
+func SchlegelDiagram(p *Polyhedron, faceID int) (*PlanarLayout, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	outer, err := planarOuterFace(p, faceID)
+	if err != nil {
+		return nil, err
+	}
+
+	center := p.calculateCentroidUnsafe()
+	faceCenter := outer.Centroid()
+	normal := outwardNormal(outer, center)
+
+	// The viewpoint must lie behind every other face's plane so only the chosen face is
+	// seen from it; the limit along the normal is the nearest such plane.
+	height := math.Inf(1)
+
+	for _, f := range p.Faces {
+		if f.ID == outer.ID {
+			continue
+		}
+
+		n := outwardNormal(f, center)
+		if along := n.Dot(normal); along > 0 {
+			height = math.Min(height, n.Dot(f.Centroid().Sub(faceCenter))/along)
+		}
+	}
+
+	if math.IsInf(height, 1) || height <= 0 {
+		height = faceCenter.Distance(center)
+	}
+
+	eye := faceCenter.Add(normal.Scale(height * schlegelViewpointFraction))
+	u := outer.Vertices[0].Position.Sub(faceCenter).Normalize()
+	w := normal.Cross(u)
+
+	positions := make(map[int]Point2, len(p.Vertices))
+
+	for id, v := range p.Vertices {
+		ray := v.Position.Sub(eye)
+		hit := v.Position
+
+		if denom := normal.Dot(ray); denom < 0 {
+			hit = eye.Add(ray.Scale(normal.Dot(faceCenter.Sub(eye)) / denom))
+		}
+
+		d := hit.Sub(faceCenter)
+		positions[id] = Point2{X: d.Dot(u), Y: d.Dot(w)}
+	}
+
+	return newPlanarLayout(p, outer, positions), nil
+}
+
+// TutteEmbedding places the vertices of one face on a regular polygon and every other
+// vertex at the average of its neighbours. For polyhedra, whose graphs are planar and
+// 3-connected, the result is a crossing-free drawing with convex faces.
+func TutteEmbedding(p *Polyhedron, faceID int) (*PlanarLayout, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	outer, err := planarOuterFace(p, faceID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[int]Point2, len(p.Vertices))
+	n := len(outer.Vertices)
+
+	for i, v := range outer.Vertices {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(n))
+		positions[v.ID] = Point2{X: cos, Y: sin}
+	}
+
+	var interior []*Vertex
+
+	for _, v := range sortedVertices(p) {
+		if _, fixed := positions[v.ID]; !fixed {
+			interior = append(interior, v)
+		}
+	}
+
+	xs, ys := solveTutte(interior, positions)
+
+	for i, v := range interior {
+		positions[v.ID] = Point2{X: xs[i], Y: ys[i]}
+	}
+
+	return newPlanarLayout(p, outer, positions), nil
+}
+
+// planarOuterFace returns the chosen outer face, checking the polyhedron can be laid out.
+func planarOuterFace(p *Polyhedron, faceID int) (*Face, error) {
+	outer, ok := p.Faces[faceID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrFaceNotFound, faceID)
+	}
+
+	if euler := len(p.Vertices) - len(p.Edges) + len(p.Faces); euler != sphereEulerCharacteristic {
+		return nil, fmt.Errorf("%w: Euler characteristic %d", ErrNotSpherical, euler)
+	}
+
+	return outer, nil
+}
+
+// solveTutte solves the Tutte equations, one per interior vertex, for the x and y
+// coordinates with the conjugate gradient method. The system is the graph Laplacian
+// restricted to interior vertices, which is symmetric positive definite when every
+// interior vertex is connected to a fixed one.
+func solveTutte(interior []*Vertex, fixed map[int]Point2) ([]float64, []float64) {
+	index := indexVertices(interior)
+	neighbours := make([][]int, len(interior))
+	degree := make([]float64, len(interior))
+	bx := make([]float64, len(interior))
+	by := make([]float64, len(interior))
+
+	for i, v := range interior {
+		for _, id := range slices.Sorted(maps.Keys(v.Edges)) {
+			other := v.Edges[id].OtherVertex(v)
+			degree[i]++
+
+			if j, ok := index[other.ID]; ok {
+				neighbours[i] = append(neighbours[i], j)
+			} else {
+				bx[i] += fixed[other.ID].X
+				by[i] += fixed[other.ID].Y
+			}
+		}
+	}
+
+	multiply := func(x, out []float64) {
+		for i := range x {
+			out[i] = degree[i] * x[i]
+
+			for _, j := range neighbours[i] {
+				out[i] -= x[j]
+			}
+		}
+	}
+
+	return conjugateGradient(multiply, bx), conjugateGradient(multiply, by)
+}
+
+// conjugateGradient solves Ax = b for a symmetric positive definite A given as a
+// matrix-vector product.
+func conjugateGradient(multiply func(x, out []float64), b []float64) []float64 {
+	x := make([]float64, len(b))
+	r := slices.Clone(b)
+	d := slices.Clone(b)
+	ad := make([]float64, len(b))
+
+	dot := func(a, b []float64) float64 {
+		sum := 0.0
+
+		for i := range a {
+			sum += a[i] * b[i]
+		}
+
+		return sum
+	}
+
+	rr := dot(r, r)
+	limit := tutteTolerance * tutteTolerance * math.Max(rr, 1)
+
+	for range tutteIterationFactor*len(b) + 1 {
+		if rr <= limit {
+			break
+		}
+
+		multiply(d, ad)
+		alpha := rr / dot(d, ad)
+
+		for i := range x {
+			x[i] += alpha * d[i]
+			r[i] -= alpha * ad[i]
+		}
+
+		next := dot(r, r)
+
+		for i := range d {
+			d[i] = r[i] + next/rr*d[i]
+		}
+
+		rr = next
+	}
+
+	return x
+}
+
+// newPlanarLayout records the edges and faces of a layout and scales the
+// positions to fit the unit disk.
+func newPlanarLayout(p *Polyhedron, outer *Face, positions map[int]Point2) *PlanarLayout {
+	radius := 0.0
+
+	for _, pt := range positions {
+		radius = math.Max(radius, math.Hypot(pt.X, pt.Y))
+	}
+
+	if radius > 0 {
+		for id, pt := range positions {
+			positions[id] = Point2{X: pt.X / radius, Y: pt.Y / radius}
+		}
+	}
+
+	layout := &PlanarLayout{
+		Positions: positions,
+		Edges:     make([][2]int, 0, len(p.Edges)),
+		Faces:     make(map[int][]int, len(p.Faces)),
+		OuterFace: outer.ID,
+		colors:    make(map[int]Color),
+	}
+
+	for _, e := range p.Edges {
+		layout.Edges = append(layout.Edges, [2]int{min(e.V1.ID, e.V2.ID), max(e.V1.ID, e.V2.ID)})
+	}
+
+	slices.SortFunc(layout.Edges, func(a, b [2]int) int { return slices.Compare(a[:], b[:]) })
+
+	colorKey := ColorAttribute()
+
+	for id, f := range p.Faces {
+		if c, ok := colorKey.Get(f); ok {
+			layout.colors[id] = c
+		}
+
+		cycle := make([]int, len(f.Vertices))
+
+		for i, v := range f.Vertices {
+			cycle[i] = v.ID
+		}
+
+		layout.Faces[id] = cycle
+	}
+
+	return layout
+}
+
+// WriteSVG draws the layout as an SVG image: faces filled with their
+// ColorAttribute where set, then edges and vertices in the style's stroke colour. The
+// outer face's colour, if any, fills the outer polygon behind the rest. Size, style
+// and background come from the options; camera and light are ignored.
+func (l *PlanarLayout) WriteSVG(w io.Writer, opts ...RenderOption) error {
+	cfg := newRenderConfig(opts)
+	halfW, halfH := float64(cfg.width)/2, float64(cfg.height)/2
+	fit := math.Min(halfW, halfH) * (1 - defaultRenderMargin)
+
+	point := func(id int) string {
+		pt := l.Positions[id]
+
+		return svgNumber(halfW+pt.X*fit) + "," + svgNumber(halfH-pt.Y*fit)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		cfg.width, cfg.height, cfg.width, cfg.height)
+
+	if bg := cfg.style.Background; bg.A > 0 {
+		fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"%s/>`+"\n", bg.Hex(), svgOpacity("fill-opacity", bg.A))
+	}
+
+	for _, id := range l.faceOrder() {
+		c := l.colors[id]
+		fmt.Fprintf(bw, `<polygon points="%s" fill="%s"%s/>`+"\n", l.svgCycle(id, point), c.Hex(), svgOpacity("fill-opacity", c.A))
+	}
+
+	stroke := cfg.style.Stroke
+	fmt.Fprintf(bw, `<g stroke="%s" stroke-width="%s" stroke-linecap="round"%s>`+"\n",
+		stroke.Hex(), svgNumber(cfg.style.StrokeWidth), svgOpacity("stroke-opacity", stroke.A))
+
+	for _, e := range l.Edges {
+		a, b := l.Positions[e[0]], l.Positions[e[1]]
+		fmt.Fprintf(bw, `<line x1="%s" y1="%s" x2="%s" y2="%s"/>`+"\n",
+			svgNumber(halfW+a.X*fit), svgNumber(halfH-a.Y*fit), svgNumber(halfW+b.X*fit), svgNumber(halfH-b.Y*fit))
+	}
+
+	bw.WriteString("</g>\n")
+	fmt.Fprintf(bw, `<g fill="%s"%s>`+"\n", stroke.Hex(), svgOpacity("fill-opacity", stroke.A))
+
+	radius := svgNumber(cfg.style.StrokeWidth * planarVertexRadius)
+
+	for _, id := range l.vertexOrder() {
+		pt := l.Positions[id]
+		fmt.Fprintf(bw, `<circle cx="%s" cy="%s" r="%s"/>`+"\n", svgNumber(halfW+pt.X*fit), svgNumber(halfH-pt.Y*fit), radius)
+	}
+
+	bw.WriteString("</g>\n</svg>\n")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing SVG: %w", err)
+	}
+
+	return nil
+}
+
+// WriteTikZ writes the layout as a TikZ picture for LaTeX documents. Vertices become
+// named coordinates v<ID>, coloured faces are filled, and edges and vertices are drawn
+// in black.
+func (l *PlanarLayout) WriteTikZ(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "\\begin{tikzpicture}[scale=%d]\n", tikzScale)
+
+	for _, id := range l.vertexOrder() {
+		pt := l.Positions[id]
+		fmt.Fprintf(bw, "  \\coordinate (v%d) at (%s, %s);\n", id, tikzNumber(pt.X), tikzNumber(pt.Y))
+	}
+
+	for _, id := range l.faceOrder() {
+		c := l.colors[id]
+		fmt.Fprintf(bw, "  \\fill[fill={rgb,1:red,%s;green,%s;blue,%s}, fill opacity=%s] %s -- cycle;\n",
+			tikzNumber(clampChannel(c.R)), tikzNumber(clampChannel(c.G)), tikzNumber(clampChannel(c.B)),
+			tikzNumber(clampChannel(c.A)), l.tikzCycle(id))
+	}
+
+	for _, e := range l.Edges {
+		fmt.Fprintf(bw, "  \\draw (v%d) -- (v%d);\n", e[0], e[1])
+	}
+
+	for _, id := range l.vertexOrder() {
+		fmt.Fprintf(bw, "  \\fill (v%d) circle[radius=0.5pt];\n", id)
+	}
+
+	bw.WriteString("\\end{tikzpicture}\n")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing TikZ: %w", err)
+	}
+
+	return nil
+}
+
+// faceOrder returns the IDs of the coloured faces to fill, outer face first.
+func (l *PlanarLayout) faceOrder() []int {
+	var ids []int
+
+	if _, ok := l.colors[l.OuterFace]; ok {
+		ids = append(ids, l.OuterFace)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(l.colors)) {
+		if id != l.OuterFace {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// vertexOrder returns the vertex IDs in ascending order.
+func (l *PlanarLayout) vertexOrder() []int {
+	return slices.Sorted(maps.Keys(l.Positions))
+}
+
+// svgCycle returns a face's boundary as an SVG points list, formatting each vertex
+// with point.
+func (l *PlanarLayout) svgCycle(faceID int, point func(id int) string) string {
+	cycle := l.Faces[faceID]
+	parts := make([]string, len(cycle))
+
+	for i, id := range cycle {
+		parts[i] = point(id)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// tikzCycle returns a face's boundary as a TikZ path through its named vertex
+// coordinates.
+func (l *PlanarLayout) tikzCycle(faceID int) string {
+	cycle := l.Faces[faceID]
+	parts := make([]string, len(cycle))
+
+	for i, id := range cycle {
+		parts[i] = "(v" + strconv.Itoa(id) + ")"
+	}
+
+	return strings.Join(parts, " -- ")
+}
+
+// tikzNumber formats a TikZ coordinate.
+func tikzNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', tikzPrecision, 64)
+}
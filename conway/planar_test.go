@@ -0,0 +1,191 @@
+package conway_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lowestFaceID returns the smallest face ID of the polyhedron.
+func lowestFaceID(p *conway.Polyhedron) int {
+	lowest := math.MaxInt
+
+	for id := range p.Faces {
+		lowest = min(lowest, id)
+	}
+
+	return lowest
+}
+
+// crossings counts pairs of edges without a common vertex that intersect in the layout.
+func crossings(l *conway.PlanarLayout) int {
+	orient := func(a, b, c conway.Point2) float64 {
+		return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+	}
+
+	count := 0
+
+	for i, e := range l.Edges {
+		for _, f := range l.Edges[i+1:] {
+			if e[0] == f[0] || e[0] == f[1] || e[1] == f[0] || e[1] == f[1] {
+				continue
+			}
+
+			a, b := l.Positions[e[0]], l.Positions[e[1]]
+			c, d := l.Positions[f[0]], l.Positions[f[1]]
+
+			if orient(a, b, c)*orient(a, b, d) < 0 && orient(c, d, a)*orient(c, d, b) < 0 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func TestSchlegelDiagram(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "C", "D", "I", "tI", "aD", "kC"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(notation)
+			outer := lowestFaceID(p)
+
+			l, err := conway.SchlegelDiagram(p, outer)
+			require.NoError(t, err)
+
+			assert.Len(t, l.Positions, len(p.Vertices))
+			assert.Len(t, l.Edges, len(p.Edges))
+			assert.Len(t, l.Faces, len(p.Faces))
+			assert.Equal(t, outer, l.OuterFace)
+			assert.Zero(t, crossings(l))
+
+			// Every other vertex is seen through the outer face, so lies inside it.
+			onOuter := make(map[int]bool)
+			for _, id := range l.Faces[outer] {
+				onOuter[id] = true
+			}
+
+			for id, pt := range l.Positions {
+				r := math.Hypot(pt.X, pt.Y)
+				assert.LessOrEqual(t, r, 1+1e-9)
+
+				if !onOuter[id] {
+					assert.Less(t, r, 1-1e-9)
+				}
+			}
+		})
+	}
+}
+
+func TestTutteEmbedding(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "C", "D", "kD", "tkC", "ktI"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(notation)
+			outer := lowestFaceID(p)
+
+			l, err := conway.TutteEmbedding(p, outer)
+			require.NoError(t, err)
+			assert.Zero(t, crossings(l))
+
+			fixed := make(map[int]bool)
+
+			for _, id := range l.Faces[outer] {
+				fixed[id] = true
+				pt := l.Positions[id]
+				assert.InDelta(t, 1, math.Hypot(pt.X, pt.Y), 1e-9, "outer vertices lie on the unit circle")
+			}
+
+			// Interior vertices sit at the barycentre of their neighbours.
+			for id, v := range p.Vertices {
+				if fixed[id] {
+					continue
+				}
+
+				var sum conway.Point2
+
+				for _, e := range v.Edges {
+					pt := l.Positions[e.OtherVertex(v).ID]
+					sum.X += pt.X
+					sum.Y += pt.Y
+				}
+
+				n := float64(len(v.Edges))
+				assert.InDelta(t, sum.X/n, l.Positions[id].X, 1e-6)
+				assert.InDelta(t, sum.Y/n, l.Positions[id].Y, 1e-6)
+			}
+		})
+	}
+}
+
+func TestPlanarLayoutErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := conway.SchlegelDiagram(conway.Cube(), -1)
+	require.ErrorIs(t, err, conway.ErrFaceNotFound)
+
+	_, err = conway.TutteEmbedding(conway.Cube(), -1)
+	require.ErrorIs(t, err, conway.ErrFaceNotFound)
+
+	open := conway.Cube()
+	open.RemoveFace(open.Faces[lowestFaceID(open)])
+
+	_, err = conway.TutteEmbedding(open, lowestFaceID(open))
+	require.ErrorIs(t, err, conway.ErrNotSpherical)
+}
+
+func TestPlanarLayoutWrite(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Dodecahedron()
+	conway.ColorAttribute().Set(p.Faces[lowestFaceID(p)], conway.RGB(1, 0, 0))
+
+	l, err := conway.TutteEmbedding(p, lowestFaceID(p))
+	require.NoError(t, err)
+
+	var svg bytes.Buffer
+
+	require.NoError(t, l.WriteSVG(&svg, conway.WithSize(200, 200)))
+
+	var doc struct {
+		Polygons []struct {
+			Fill string `xml:"fill,attr"`
+		} `xml:"polygon"`
+		Groups []struct {
+			Lines   []struct{} `xml:"line"`
+			Circles []struct{} `xml:"circle"`
+		} `xml:"g"`
+	}
+
+	require.NoError(t, xml.Unmarshal(svg.Bytes(), &doc))
+	require.Len(t, doc.Polygons, 1, "only coloured faces are filled")
+	assert.Equal(t, "#ff0000", doc.Polygons[0].Fill)
+	require.Len(t, doc.Groups, 2)
+	assert.Len(t, doc.Groups[0].Lines, 30)
+	assert.Len(t, doc.Groups[1].Circles, 20)
+
+	var tikz bytes.Buffer
+
+	require.NoError(t, l.WriteTikZ(&tikz))
+
+	out := tikz.String()
+	assert.True(t, strings.HasPrefix(out, "\\begin{tikzpicture}"))
+	assert.True(t, strings.HasSuffix(out, "\\end{tikzpicture}\n"))
+	assert.Equal(t, 20, strings.Count(out, "\\coordinate"))
+	assert.Equal(t, 30, strings.Count(out, "\\draw"))
+	assert.Equal(t, 1, strings.Count(out, "red,1.0000;green,0.0000"))
+
+	assert.ErrorIs(t, l.WriteTikZ(failingWriter{}), errWriteFailed)
+}
@@ -104,6 +104,8 @@ type Vertex struct {
 	Position Vector3       // 3D coordinates of the vertex
 	Edges    map[int]*Edge // All edges incident to this vertex
 	Faces    map[int]*Face // All faces containing this vertex
+
+	attrs attributeSet // User attributes, see AttributeKey
 }
 
 // NewVertex creates a new vertex with the given ID and position.
@@ -114,6 +116,7 @@ func NewVertex(id int, pos Vector3) *Vertex {
 		Position: pos,
 		Edges:    make(map[int]*Edge),
 		Faces:    make(map[int]*Face),
+		attrs:    nil,
 	}
 }
 
@@ -131,6 +134,8 @@ type Edge struct {
 	V1    *Vertex       // First endpoint vertex
 	V2    *Vertex       // Second endpoint vertex
 	Faces map[int]*Face // Adjacent faces (typically 2 for manifold edges)
+
+	attrs attributeSet // User attributes, see AttributeKey
 }
 
 // NewEdge creates a new edge connecting vertices v1 and v2.
@@ -141,6 +146,7 @@ func NewEdge(id int, v1, v2 *Vertex) *Edge {
 		V1:    v1,
 		V2:    v2,
 		Faces: make(map[int]*Face),
+		attrs: nil,
 	}
 }
 
@@ -177,6 +183,8 @@ type Face struct {
 	Vertices []*Vertex // Ordered vertices forming the face boundary (CCW from outside)
 	Edges    []*Edge   // Edges bounding the face
 
+	attrs attributeSet // User attributes, see AttributeKey
+
 	// Cached computed properties.
 	cachedNormal   *Vector3     // Cached face normal vector
 	cachedCentroid *Vector3     // Cached face centroid
@@ -189,6 +197,7 @@ func NewFace(id int, vertices []*Vertex) *Face {
 		ID:             id,
 		Vertices:       vertices,
 		Edges:          allocateEdgeSlice(len(vertices)), // Pre-allocate with expected capacity
+		attrs:          nil,
 		cachedNormal:   nil,
 		cachedCentroid: nil,
 		cachedArea:     nil,
@@ -292,16 +301,15 @@ func (f *Face) Area() float64 {
 		return 0
 	}
 
-	area := 0.0
-
-	for i := 1; i < len(f.Vertices)-1; i++ {
-		v1 := f.Vertices[i].Position.Sub(f.Vertices[0].Position)
+	points := make([]Vector3, len(f.Vertices))
 
-		v2 := f.Vertices[i+1].Position.Sub(f.Vertices[0].Position)
-
-		area += v1.Cross(v2).Length() * halfScale
+	for i, v := range f.Vertices {
+		points[i] = v.Position
 	}
 
+	// Newell's vector is exact for non-convex faces, where a fan's triangles overlap.
+	area := newellVector(points).Length() * halfScale
+
 	f.cachedArea = &area
 
 	return area
@@ -321,6 +329,7 @@ type Polyhedron struct {
 	Vertices   map[int]*Vertex // All vertices indexed by ID
 	Edges      map[int]*Edge   // All edges indexed by ID
 	Faces      map[int]*Face   // All faces indexed by ID
+	Provenance *Provenance     // Source of each element in the operation's input, nil for seeds
 	nextID     int64           // Atomic counter for next available ID
 	edgeLookup *EdgeLookup     // O(1) edge lookup by vertex pair
 	mu         sync.RWMutex    // Read-write mutex for thread safety
@@ -337,6 +346,7 @@ func NewPolyhedron(name string) *Polyhedron {
 		Vertices:       make(map[int]*Vertex),
 		Edges:          make(map[int]*Edge),
 		Faces:          make(map[int]*Face),
+		Provenance:     nil,
 		nextID:         0,
 		edgeLookup:     NewEdgeLookup(),
 		mu:             sync.RWMutex{},
@@ -393,13 +403,19 @@ func (p *Polyhedron) addEdgeUnsafe(v1, v2 *Vertex) *Edge {
 // AddFace creates a new face from the given ordered vertices.
 // Automatically creates edges between consecutive vertices and updates all connectivity.
 // Vertices should be ordered counter-clockwise when viewed from outside the polyhedron.
+// A face sharing edges with existing faces is wound to traverse them opposite to its
+// neighbours, so orientation propagates topologically and holds for non-convex shapes.
+// Only a face with no neighbours yet is checked against the polyhedron's centroid;
+// Orient rewinds a finished polyhedron whose first face was misjudged.
 // Thread-safe for concurrent access.
 func (p *Polyhedron) AddFace(vertices []*Vertex) *Face {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	vertices, decided := p.orientAgainstNeighboursUnsafe(vertices)
+
 	// Ensure proper winding order if we have a meaningful polyhedron center.
-	if len(p.Vertices) > 3 {
+	if !decided && len(p.Vertices) > 3 {
 		center := p.calculateCentroidUnsafe()
 
 		vertices = EnsureCounterClockwise(vertices, center)
@@ -407,12 +423,20 @@ func (p *Polyhedron) AddFace(vertices []*Vertex) *Face {
 
 	f := NewFace(p.getNextID(), vertices)
 
+	p.linkFaceUnsafe(f)
+
+	return f
+}
+
+// linkFaceUnsafe registers a face and creates or reuses the edges along its
+// boundary, updating all connectivity. It performs no locking.
+func (p *Polyhedron) linkFaceUnsafe(f *Face) {
 	p.Faces[f.ID] = f
 
-	for i := 0; i < len(vertices); i++ {
-		v1 := vertices[i]
+	for i := 0; i < len(f.Vertices); i++ {
+		v1 := f.Vertices[i]
 
-		v2 := vertices[(i+1)%len(vertices)]
+		v2 := f.Vertices[(i+1)%len(f.Vertices)]
 
 		e := p.addEdgeUnsafe(v1, v2)
 
@@ -421,8 +445,6 @@ func (p *Polyhedron) AddFace(vertices []*Vertex) *Face {
 
 		v1.Faces[f.ID] = f
 	}
-
-	return f
 }
 
 // invalidateCache invalidates all cached properties.
@@ -557,8 +579,8 @@ func (p *Polyhedron) IsValid() bool {
 }
 
 // Clone creates a deep copy of the polyhedron.
-// All vertices, edges, and faces are recreated with new IDs,.
-// but the geometric and topological structure is preserved.
+// All vertices, edges, and faces are recreated with new IDs,
+// but the geometric and topological structure, including each face's winding, is preserved.
 // Thread-safe for concurrent access.
 func (p *Polyhedron) Clone() *Polyhedron {
 	p.mu.RLock()
@@ -569,13 +591,17 @@ func (p *Polyhedron) Clone() *Polyhedron {
 	// Pre-allocate vertex map with known size.
 	vertexMap := make(map[int]*Vertex, len(p.Vertices))
 
-	for _, v := range p.Vertices {
+	// Copy in ID order so clones of the same polyhedron are identical.
+	for _, v := range sortedVertices(p) {
 		newV := newP.AddVertex(v.Position)
+		newV.attrs = cloneAttributes(v.attrs)
 
 		vertexMap[v.ID] = newV
 	}
 
-	for _, f := range p.Faces {
+	faceIDs := make(map[int]int, len(p.Faces))
+
+	for _, f := range sortedFaces(p) {
 		// Pre-allocate slice with exact size needed.
 		newVertices := make([]*Vertex, len(f.Vertices))
 
@@ -583,7 +609,32 @@ func (p *Polyhedron) Clone() *Polyhedron {
 			newVertices[i] = vertexMap[v.ID]
 		}
 
-		newP.AddFace(newVertices)
+		// Faces are linked as stored rather than through AddFace, which would rewind
+		// them, so deliberately inward-facing faces such as a shell's cavity survive.
+		newF := NewFace(newP.getNextID(), newVertices)
+		newP.linkFaceUnsafe(newF)
+		newF.attrs = cloneAttributes(f.attrs)
+
+		faceIDs[f.ID] = newF.ID
+	}
+
+	edgeIDs := make(map[int]int, len(p.Edges))
+
+	for _, e := range p.Edges {
+		if newE := newP.edgeLookup.Find(vertexMap[e.V1.ID].ID, vertexMap[e.V2.ID].ID); newE != nil {
+			newE.attrs = cloneAttributes(e.attrs)
+			edgeIDs[e.ID] = newE.ID
+		}
+	}
+
+	if p.Provenance != nil {
+		vertexIDs := make(map[int]int, len(vertexMap))
+
+		for id, v := range vertexMap {
+			vertexIDs[id] = v.ID
+		}
+
+		newP.Provenance = p.Provenance.remap(vertexIDs, edgeIDs, faceIDs)
 	}
 
 	return newP
@@ -615,8 +666,9 @@ func (p *Polyhedron) calculateCentroidUnsafe() Vector3 {
 		return Vector3{X: 0, Y: 0, Z: 0}
 	}
 
+	// Sum in ID order so the result does not depend on map iteration order.
 	sum := Vector3{X: 0, Y: 0, Z: 0}
-	for _, v := range p.Vertices {
+	for _, v := range sortedVertices(p) {
 		sum = sum.Add(v.Position)
 	}
 
@@ -0,0 +1,231 @@
+package conway
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Static errors for err113 compliance.
+var (
+	ErrInvalidElementKind = errors.New("invalid element kind")
+)
+
+// ElementKind identifies the type of a polyhedron element.
+type ElementKind int
+
+const (
+	// VertexElement is a vertex.
+	VertexElement ElementKind = iota
+	// EdgeElement is an edge.
+	EdgeElement
+	// FaceElement is a face.
+	FaceElement
+)
+
+// elementKinds returns every element kind in encoding order.
+func elementKinds() []ElementKind {
+	return []ElementKind{VertexElement, EdgeElement, FaceElement}
+}
+
+// valid reports whether k is one of the defined element kinds.
+func (k ElementKind) valid() bool {
+	return k >= VertexElement && k <= FaceElement
+}
+
+// String returns the lower-case name of the element kind.
+func (k ElementKind) String() string {
+	switch k {
+	case VertexElement:
+		return "vertex"
+	case EdgeElement:
+		return "edge"
+	case FaceElement:
+		return "face"
+	default:
+		return fmt.Sprintf("ElementKind(%d)", int(k))
+	}
+}
+
+// Source identifies the element of an input polyhedron that an output element came from.
+type Source struct {
+	Kind ElementKind // Kind of the source element
+	ID   int         // ID of the source element in the input polyhedron
+}
+
+// Role describes how an element was derived, such as a face created from a vertex.
+type Role struct {
+	Element ElementKind // Kind of the derived element
+	Source  ElementKind // Kind of the element it came from
+}
+
+// String returns the role in the form "face from vertex".
+func (r Role) String() string {
+	return r.Element.String() + " from " + r.Source.String()
+}
+
+// Provenance maps every element of an operation's output to the element of its
+// input it came from. For example, truncation maps the faces at the cut corners
+// to the original vertices ("face from vertex") and the shrunken faces to the
+// original faces ("face from face").
+//
+// Each operation records one step and links it to the provenance of its input
+// through Parent, so the chain for "tkC" has a truncate step whose parent is the
+// kis step. Resolve composes the chain into a single map back to the seed.
+type Provenance struct {
+	Operation string         // Symbol of the operation, e.g. "t", or the composed symbols
+	Vertices  map[int]Source // Output vertex ID to source element
+	Edges     map[int]Source // Output edge ID to source element
+	Faces     map[int]Source // Output face ID to source element
+	Parent    *Provenance    // Provenance of the input polyhedron, nil if the input was a seed
+}
+
+// newProvenance creates an empty provenance step for an operation.
+func newProvenance(operation string, parent *Provenance) *Provenance {
+	return &Provenance{
+		Operation: operation,
+		Vertices:  make(map[int]Source),
+		Edges:     make(map[int]Source),
+		Faces:     make(map[int]Source),
+		Parent:    parent,
+	}
+}
+
+// elements returns the map for an element kind.
+func (pr *Provenance) elements(kind ElementKind) map[int]Source {
+	switch kind {
+	case VertexElement:
+		return pr.Vertices
+	case EdgeElement:
+		return pr.Edges
+	case FaceElement:
+		return pr.Faces
+	default:
+		return nil
+	}
+}
+
+// Source returns the input element that the output element of the given kind and ID came from.
+func (pr *Provenance) Source(kind ElementKind, id int) (Source, bool) {
+	if pr == nil {
+		return Source{}, false
+	}
+
+	src, ok := pr.elements(kind)[id]
+
+	return src, ok
+}
+
+// Role returns how the output element of the given kind and ID was derived.
+func (pr *Provenance) Role(kind ElementKind, id int) (Role, bool) {
+	src, ok := pr.Source(kind, id)
+	if !ok {
+		return Role{}, false
+	}
+
+	return Role{Element: kind, Source: src.Kind}, true
+}
+
+// Compose maps this step through an earlier one, returning provenance from this
+// step's output directly to the input of earlier. Elements whose source is not
+// covered by earlier are dropped. The result's parent is earlier's parent.
+func (pr *Provenance) Compose(earlier *Provenance) *Provenance {
+	composed := newProvenance(pr.Operation+earlier.Operation, earlier.Parent)
+
+	for _, kind := range elementKinds() {
+		target := composed.elements(kind)
+
+		for id, src := range pr.elements(kind) {
+			if origin, ok := earlier.elements(src.Kind)[src.ID]; ok {
+				target[id] = origin
+			}
+		}
+	}
+
+	return composed
+}
+
+// Resolve composes the whole chain, returning provenance from this step's output
+// back to the seed the chain started from.
+func (pr *Provenance) Resolve() *Provenance {
+	if pr == nil {
+		return nil
+	}
+
+	resolved := pr
+
+	for resolved.Parent != nil {
+		resolved = resolved.Compose(resolved.Parent)
+	}
+
+	return resolved
+}
+
+// Depth returns the number of steps in the chain.
+func (pr *Provenance) Depth() int {
+	depth := 0
+
+	for step := pr; step != nil; step = step.Parent {
+		depth++
+	}
+
+	return depth
+}
+
+// collapse composes the newest steps of the chain into a single step recorded
+// under symbol. Compound operations use it so their output maps to their own input.
+func (pr *Provenance) collapse(steps int, symbol string) *Provenance {
+	if pr == nil {
+		return nil
+	}
+
+	collapsed := pr
+
+	for i := 1; i < steps && collapsed.Parent != nil; i++ {
+		collapsed = collapsed.Compose(collapsed.Parent)
+	}
+
+	// The maps are never modified after an operation returns, so a shallow copy is safe.
+	renamed := *collapsed
+	renamed.Operation = symbol
+
+	return &renamed
+}
+
+// remap returns a copy of the provenance with output IDs translated through the given maps.
+// Clone uses it to keep provenance attached to the copied elements.
+func (pr *Provenance) remap(vertexIDs, edgeIDs, faceIDs map[int]int) *Provenance {
+	if pr == nil {
+		return nil
+	}
+
+	remapped := newProvenance(pr.Operation, pr.Parent)
+	idMaps := map[ElementKind]map[int]int{VertexElement: vertexIDs, EdgeElement: edgeIDs, FaceElement: faceIDs}
+
+	for kind, ids := range idMaps {
+		target := remapped.elements(kind)
+
+		for id, src := range pr.elements(kind) {
+			if newID, ok := ids[id]; ok {
+				target[newID] = src
+			}
+		}
+	}
+
+	return remapped
+}
+
+// String summarizes the chain from the newest step, e.g. "t <- k <- seed".
+func (pr *Provenance) String() string {
+	if pr == nil {
+		return "seed"
+	}
+
+	symbols := make([]string, 0, pr.Depth())
+
+	for step := pr; step != nil; step = step.Parent {
+		symbols = append(symbols, step.Operation)
+	}
+
+	return strings.Join(symbols, " <- ") + " <- seed"
+}
@@ -0,0 +1,223 @@
+package conway_test
+
+import (
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countRoles tallies the roles of every element in a polyhedron.
+func countRoles(t *testing.T, p *conway.Polyhedron, pr *conway.Provenance) map[string]int {
+	t.Helper()
+
+	counts := make(map[string]int)
+
+	count := func(kind conway.ElementKind, id int) {
+		role, ok := pr.Role(kind, id)
+		require.True(t, ok, "%s %d has no provenance", kind, id)
+
+		counts[role.String()]++
+	}
+
+	for id := range p.Vertices {
+		count(conway.VertexElement, id)
+	}
+
+	for id := range p.Edges {
+		count(conway.EdgeElement, id)
+	}
+
+	for id := range p.Faces {
+		count(conway.FaceElement, id)
+	}
+
+	return counts
+}
+
+func TestProvenanceRoles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		notation string
+		roles    map[string]int
+	}{
+		{"kC", map[string]int{
+			"vertex from vertex": 8, "vertex from face": 6,
+			"edge from edge": 12, "edge from face": 24,
+			"face from face": 24,
+		}},
+		{"tC", map[string]int{
+			"vertex from edge": 24,
+			"edge from edge":   12, "edge from vertex": 24,
+			"face from face": 6, "face from vertex": 8,
+		}},
+		{"aC", map[string]int{
+			"vertex from edge": 12,
+			"edge from face":   24,
+			"face from face":   6, "face from vertex": 8,
+		}},
+		{"dC", map[string]int{
+			"vertex from face": 6,
+			"edge from edge":   12,
+			"face from vertex": 8,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(tt.notation)
+
+			require.NotNil(t, p.Provenance)
+			assert.Equal(t, tt.roles, countRoles(t, p, p.Provenance))
+			assert.Nil(t, p.Provenance.Parent)
+		})
+	}
+}
+
+func TestProvenanceSourcesExistInInput(t *testing.T) {
+	t.Parallel()
+
+	input := conway.MustParse("kC")
+	output := conway.Truncate(input)
+
+	pr := output.Provenance
+	require.NotNil(t, pr)
+	assert.Same(t, input.Provenance, pr.Parent)
+
+	for id := range output.Faces {
+		src, ok := pr.Source(conway.FaceElement, id)
+		require.True(t, ok)
+
+		switch src.Kind {
+		case conway.VertexElement:
+			assert.Contains(t, input.Vertices, src.ID)
+		case conway.FaceElement:
+			assert.Contains(t, input.Faces, src.ID)
+		default:
+			t.Fatalf("unexpected source kind %s", src.Kind)
+		}
+	}
+}
+
+func TestProvenanceResolve(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("tkC")
+	seed := conway.Cube()
+
+	assert.Equal(t, 2, p.Provenance.Depth())
+	assert.Equal(t, "t <- k <- seed", p.Provenance.String())
+
+	resolved := p.Provenance.Resolve()
+	assert.Equal(t, "tk", resolved.Operation)
+	assert.Nil(t, resolved.Parent)
+
+	// tkC has the cube's vertices as 3-valent corners plus one 4-valent corner per
+	// face; the faces cut from them resolve to cube vertices and faces respectively.
+	counts := countRoles(t, p, resolved)
+	assert.Equal(t, 8, counts["face from vertex"])
+	assert.Equal(t, 6+24, counts["face from face"])
+
+	for id := range p.Faces {
+		src, _ := resolved.Source(conway.FaceElement, id)
+
+		if src.Kind == conway.VertexElement {
+			assert.Contains(t, seed.Vertices, src.ID)
+		} else {
+			assert.Contains(t, seed.Faces, src.ID)
+		}
+	}
+}
+
+func TestProvenanceCompoundOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		notation string
+		roles    map[string]int
+	}{
+		// Join is implemented as ambo of the dual, so its vertices sit on the original edges.
+		{"jC", map[string]int{
+			"vertex from edge": 12,
+			"edge from vertex": 24,
+			"face from face":   6, "face from vertex": 8,
+		}},
+		// Expand keeps the faces and vertices and adds a square for each edge.
+		{"eC", map[string]int{
+			"vertex from face": 24,
+			"edge from face":   24, "edge from vertex": 24,
+			"face from face": 6, "face from vertex": 8, "face from edge": 12,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(tt.notation)
+
+			assert.Equal(t, 1, p.Provenance.Depth())
+			assert.Equal(t, tt.notation[:1], p.Provenance.Operation)
+			assert.Equal(t, tt.roles, countRoles(t, p, p.Provenance))
+		})
+	}
+
+	for _, notation := range []string{"oC", "gC", "sC"} {
+		p := conway.MustParse(notation)
+
+		assert.Equal(t, 1, p.Provenance.Depth(), notation)
+		assert.Len(t, p.Provenance.Faces, len(p.Faces), notation)
+	}
+}
+
+func TestProvenanceSeed(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("C")
+
+	assert.Nil(t, p.Provenance)
+	assert.Nil(t, p.Provenance.Resolve())
+	assert.Equal(t, "seed", p.Provenance.String())
+
+	_, ok := p.Provenance.Source(conway.VertexElement, 1)
+	assert.False(t, ok)
+}
+
+func TestProvenanceSurvivesClone(t *testing.T) {
+	t.Parallel()
+
+	original := conway.MustParse("tkC")
+	clone := original.Clone()
+
+	require.NotNil(t, clone.Provenance)
+	assert.Same(t, original.Provenance.Parent, clone.Provenance.Parent)
+	assert.Equal(t, countRoles(t, original, original.Provenance), countRoles(t, clone, clone.Provenance))
+}
+
+func TestProvenanceSerialization(t *testing.T) {
+	t.Parallel()
+
+	original := conway.MustParse("tkC")
+
+	binaryData, err := original.MarshalBinary()
+	require.NoError(t, err)
+
+	fromBinary := conway.NewPolyhedron("x")
+	require.NoError(t, fromBinary.UnmarshalBinary(binaryData))
+	assert.Equal(t, original.Provenance, fromBinary.Provenance)
+
+	jsonData, err := original.MarshalJSON()
+	require.NoError(t, err)
+
+	fromJSON := conway.NewPolyhedron("x")
+	require.NoError(t, fromJSON.UnmarshalJSON(jsonData))
+	assert.Equal(t, original.Provenance, fromJSON.Provenance)
+
+	bad := []byte(`{"version":1,"name":"x","vertices":[],"faces":[],` +
+		`"provenance":[{"operation":"t","vertices":[{"id":1,"kind":"corner","source":1}],"edges":[],"faces":[]}]}`)
+	require.ErrorIs(t, conway.NewPolyhedron("x").UnmarshalJSON(bad), conway.ErrInvalidElementKind)
+}
@@ -0,0 +1,157 @@
+package conway
+
+import (
+	"fmt"
+	"math"
+)
+
+// sphereTolerance is the largest spread of distances, relative to their mean, at
+// which vertices, edges or faces are taken to touch a common sphere.
+const sphereTolerance = 1e-9
+
+// NormalizationMode selects which measurement NormalizeTo scales to one.
+type NormalizationMode int
+
+const (
+	// UnitCircumradius puts the furthest vertex at unit distance from the centroid, as
+	// Normalize does. It is the default.
+	UnitCircumradius NormalizationMode = iota
+	// UnitEdgeLength makes the mean edge length one.
+	UnitEdgeLength
+	// UnitMidradius makes the mean distance from the centroid to the edges one.
+	UnitMidradius
+	// UnitInradius puts the nearest face plane at unit distance from the centroid.
+	UnitInradius
+	// UnitVolume makes the enclosed volume one.
+	UnitVolume
+)
+
+// String returns the mode's name.
+func (m NormalizationMode) String() string {
+	switch m {
+	case UnitCircumradius:
+		return "unit-circumradius"
+	case UnitEdgeLength:
+		return "unit-edge-length"
+	case UnitMidradius:
+		return "unit-midradius"
+	case UnitInradius:
+		return "unit-inradius"
+	case UnitVolume:
+		return "unit-volume"
+	default:
+		return fmt.Sprintf("NormalizationMode(%d)", int(m))
+	}
+}
+
+// Radii describes the spheres centred on a polyhedron's centroid that pass through
+// its vertices, touch its edges and touch its faces. A sphere exists when every
+// element is at the same distance; otherwise the radius is the nearest fit described
+// on each field.
+type Radii struct {
+	// Circumradius is the distance to the furthest vertex.
+	Circumradius float64
+	// Midradius is the mean distance to the lines through the edges.
+	Midradius float64
+	// Inradius is the distance to the nearest face plane.
+	Inradius float64
+
+	HasCircumsphere bool // All vertices are equidistant from the centroid
+	HasMidsphere    bool // All edges are tangent to a common sphere
+	HasInsphere     bool // All faces are tangent to a common sphere
+}
+
+// Radii returns the polyhedron's circumradius, midradius and inradius about its
+// centroid and whether each sphere exists.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) Radii() Radii {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	center := p.calculateCentroidUnsafe()
+
+	var vertexDistances, edgeDistances, faceDistances []float64
+
+	for _, v := range sortedVertices(p) {
+		vertexDistances = append(vertexDistances, v.Position.Distance(center))
+	}
+
+	for _, e := range sortedEdges(p) {
+		direction := e.V2.Position.Sub(e.V1.Position).Normalize()
+		offset := center.Sub(e.V1.Position)
+		edgeDistances = append(edgeDistances, offset.Sub(direction.Scale(offset.Dot(direction))).Length())
+	}
+
+	for _, f := range sortedFaces(p) {
+		faceDistances = append(faceDistances, math.Abs(f.Normal().Dot(f.Centroid().Sub(center))))
+	}
+
+	circumMin, circumMax, _ := distanceRange(vertexDistances)
+	midMin, midMax, midMean := distanceRange(edgeDistances)
+	inMin, inMax, _ := distanceRange(faceDistances)
+
+	return Radii{
+		Circumradius:    circumMax,
+		Midradius:       midMean,
+		Inradius:        inMin,
+		HasCircumsphere: len(vertexDistances) > 0 && circumMax-circumMin <= sphereTolerance*circumMax,
+		HasMidsphere:    len(edgeDistances) > 0 && midMax-midMin <= sphereTolerance*midMax,
+		HasInsphere:     len(faceDistances) > 0 && inMax-inMin <= sphereTolerance*inMax,
+	}
+}
+
+// distanceRange returns the smallest, largest and mean of the distances, or zeros
+// if there are none.
+func distanceRange(distances []float64) (float64, float64, float64) {
+	if len(distances) == 0 {
+		return 0, 0, 0
+	}
+
+	lowest, highest, sum := math.Inf(1), math.Inf(-1), 0.0
+
+	for _, d := range distances {
+		lowest = math.Min(lowest, d)
+		highest = math.Max(highest, d)
+		sum += d
+	}
+
+	return lowest, highest, sum / float64(len(distances))
+}
+
+// NormalizeTo centers the polyhedron at the origin and scales it so the measurement
+// selected by mode is one. NormalizeTo(UnitCircumradius) is the same as Normalize.
+// All cached properties are invalidated after normalization.
+func (p *Polyhedron) NormalizeTo(mode NormalizationMode) {
+	p.Normalize()
+
+	var measure float64
+
+	switch mode {
+	case UnitCircumradius:
+		return
+	case UnitEdgeLength:
+		for _, e := range p.Edges {
+			measure += e.Length() / float64(len(p.Edges))
+		}
+	case UnitMidradius:
+		measure = p.Radii().Midradius
+	case UnitInradius:
+		measure = p.Radii().Inradius
+	case UnitVolume:
+		measure = math.Cbrt(math.Abs(p.Volume()))
+	}
+
+	if measure <= 0 {
+		return
+	}
+
+	for _, v := range p.Vertices {
+		v.Position = v.Position.Scale(1 / measure)
+	}
+
+	p.invalidateCache()
+
+	for _, f := range p.Faces {
+		f.invalidateFaceCache()
+	}
+}
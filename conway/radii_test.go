@@ -0,0 +1,104 @@
+package conway_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRadii(t *testing.T) {
+	t.Parallel()
+
+	// A cube of edge s has circumradius s√3/2, midradius s√2/2 and inradius s/2.
+	cube := conway.Cube()
+	side := cube.Edges[lowestEdgeID(cube)].Length()
+	radii := cube.Radii()
+
+	assert.InDelta(t, side*math.Sqrt(3)/2, radii.Circumradius, 1e-12)
+	assert.InDelta(t, side*math.Sqrt2/2, radii.Midradius, 1e-12)
+	assert.InDelta(t, side/2, radii.Inradius, 1e-12)
+	assert.True(t, radii.HasCircumsphere)
+	assert.True(t, radii.HasMidsphere)
+	assert.True(t, radii.HasInsphere)
+
+	tests := []struct {
+		notation                          string
+		circumsphere, midsphere, insphere bool
+	}{
+		{"aC", true, true, false},  // Cuboctahedron: triangles and squares lie at different depths
+		{"daC", false, true, true}, // Rhombic dodecahedron: corners of degree 3 and 4
+	}
+
+	for _, tt := range tests {
+		radii := conway.MustParse(tt.notation).Radii()
+
+		assert.Equal(t, tt.circumsphere, radii.HasCircumsphere, tt.notation)
+		assert.Equal(t, tt.midsphere, radii.HasMidsphere, tt.notation)
+		assert.Equal(t, tt.insphere, radii.HasInsphere, tt.notation)
+		assert.LessOrEqual(t, radii.Inradius, radii.Midradius, tt.notation)
+		assert.LessOrEqual(t, radii.Midradius, radii.Circumradius, tt.notation)
+	}
+}
+
+func TestNormalizeTo(t *testing.T) {
+	t.Parallel()
+
+	measures := map[conway.NormalizationMode]func(*conway.Polyhedron) float64{
+		conway.UnitCircumradius: func(p *conway.Polyhedron) float64 { return p.Radii().Circumradius },
+		conway.UnitEdgeLength: func(p *conway.Polyhedron) float64 {
+			total := 0.0
+
+			for _, e := range p.Edges {
+				total += e.Length()
+			}
+
+			return total / float64(len(p.Edges))
+		},
+		conway.UnitMidradius: func(p *conway.Polyhedron) float64 { return p.Radii().Midradius },
+		conway.UnitInradius:  func(p *conway.Polyhedron) float64 { return p.Radii().Inradius },
+		conway.UnitVolume:    (*conway.Polyhedron).Volume,
+	}
+
+	for mode, measure := range measures {
+		t.Run(mode.String(), func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse("tI")
+			p.NormalizeTo(mode)
+
+			assert.InDelta(t, 1, measure(p), 1e-9)
+			assert.InDelta(t, 0, p.Centroid().Length(), 1e-9)
+
+			parsed, err := conway.Parse("tI", conway.WithNormalization(mode))
+			require.NoError(t, err)
+			assert.InDelta(t, 1, measure(parsed), 1e-9)
+		})
+	}
+}
+
+func TestNormalizationCacheKeys(t *testing.T) {
+	t.Parallel()
+
+	cache, err := conway.NewDiskCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+
+	keys := make(map[string]bool)
+
+	for _, opts := range [][]conway.Option{
+		nil,
+		{conway.WithNormalization(conway.UnitEdgeLength)},
+		{conway.WithNormalization(conway.UnitVolume)},
+		{conway.WithNormalization(conway.UnitVolume), conway.WithPrincipalAxes()},
+	} {
+		key, err := cache.Key("tI", opts...)
+		require.NoError(t, err)
+
+		keys[key] = true
+	}
+
+	assert.Len(t, keys, 4, "every normalization is cached separately")
+	assert.Equal(t, "NormalizationMode(9)", conway.NormalizationMode(9).String())
+}
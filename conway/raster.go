@@ -0,0 +1,221 @@
+package conway
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+const (
+	// pixelCenter offsets integer pixel coordinates to the centre of the pixel.
+	pixelCenter = 0.5
+	// degenerateArea is the twice-area below which a projected triangle is skipped.
+	degenerateArea = 1e-12
+)
+
+// RenderImage rasterizes the polyhedron into an image using a z-buffer, so faces
+// hide each other correctly regardless of drawing order. Faces are flat-shaded as in
+// WriteSVG and edges are drawn in the style's stroke colour. Polygon and edge
+// boundaries are anti-aliased by supersampling; see WithAntialiasing.
+//
+// Translucent faces are composited over the background but do not show the faces
+// behind them.
+func RenderImage(p *Polyhedron, opts ...RenderOption) *image.RGBA {
+	cfg := newRenderConfig(opts)
+	width, height := max(cfg.width, 0), max(cfg.height, 0)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	if width == 0 || height == 0 {
+		return img
+	}
+
+	buf := newSampleBuffer(width*cfg.samples, height*cfg.samples)
+	strokeHalfWidth := 0.0
+
+	if cfg.style.Stroke.A > 0 {
+		strokeHalfWidth = cfg.style.StrokeWidth * float64(cfg.samples) / 2
+	}
+
+	for _, f := range projectScene(p, cfg) {
+		points := make([]Vector3, len(f.points))
+
+		for i, pt := range f.points {
+			points[i] = Vector3{X: pt.X * float64(cfg.samples), Y: pt.Y * float64(cfg.samples), Z: pt.Z}
+		}
+
+		buf.fillPolygon(points, f.color, cfg.style.Stroke, strokeHalfWidth)
+	}
+
+	buf.resolve(img, cfg.samples, cfg.style.Background)
+
+	return img
+}
+
+// WritePNG renders the polyhedron with RenderImage and encodes it as PNG.
+func WritePNG(w io.Writer, p *Polyhedron, opts ...RenderOption) error {
+	if err := png.Encode(w, RenderImage(p, opts...)); err != nil {
+		return fmt.Errorf("writing PNG: %w", err)
+	}
+
+	return nil
+}
+
+// sampleBuffer is a supersampled colour and depth buffer.
+type sampleBuffer struct {
+	width, height int
+	color         []Color
+	depth         []float64 // Larger is nearer; -Inf where nothing has been drawn
+}
+
+func newSampleBuffer(width, height int) *sampleBuffer {
+	depth := make([]float64, width*height)
+
+	for i := range depth {
+		depth[i] = math.Inf(-1)
+	}
+
+	return &sampleBuffer{width: width, height: height, color: make([]Color, width*height), depth: depth}
+}
+
+// fillPolygon draws a convex or star-shaped polygon as a fan of triangles, testing
+// each sample against the depth buffer. Samples within strokeHalfWidth of the
+// polygon's boundary take the stroke colour; since neighbouring faces each draw half
+// of a shared edge, visible edges come out strokeHalfWidth*2 wide.
+func (b *sampleBuffer) fillPolygon(points []Vector3, fill, stroke Color, strokeHalfWidth float64) {
+	for i := 1; i+1 < len(points); i++ {
+		b.fillTriangle(points[0], points[i], points[i+1], func(x, y float64) Color {
+			if strokeHalfWidth > 0 && boundaryDistance(points, x, y) < strokeHalfWidth {
+				return compositeOver(stroke, fill)
+			}
+
+			return fill
+		})
+	}
+}
+
+// fillTriangle draws the samples whose centres lie inside the triangle and in front
+// of what has been drawn, interpolating depth linearly across the triangle.
+func (b *sampleBuffer) fillTriangle(v0, v1, v2 Vector3, shade func(x, y float64) Color) {
+	area := edgeFunction(v0, v1, v2.X, v2.Y)
+	if math.Abs(area) < degenerateArea {
+		return
+	}
+
+	minX := max(int(math.Floor(min(v0.X, v1.X, v2.X))), 0)
+	maxX := min(int(math.Ceil(max(v0.X, v1.X, v2.X))), b.width-1)
+	minY := max(int(math.Floor(min(v0.Y, v1.Y, v2.Y))), 0)
+	maxY := min(int(math.Ceil(max(v0.Y, v1.Y, v2.Y))), b.height-1)
+
+	for y := minY; y <= maxY; y++ {
+		sy := float64(y) + pixelCenter
+
+		for x := minX; x <= maxX; x++ {
+			sx := float64(x) + pixelCenter
+
+			// Barycentric weights; all share the sign of area inside the triangle.
+			w0 := edgeFunction(v1, v2, sx, sy) / area
+			w1 := edgeFunction(v2, v0, sx, sy) / area
+			w2 := edgeFunction(v0, v1, sx, sy) / area
+
+			if w0 < 0 || w1 < 0 || w2 < 0 {
+				continue
+			}
+
+			i := y*b.width + x
+			z := w0*v0.Z + w1*v1.Z + w2*v2.Z
+
+			if z <= b.depth[i] {
+				continue
+			}
+
+			b.depth[i] = z
+			b.color[i] = shade(sx, sy)
+		}
+	}
+}
+
+// resolve averages each block of samples into a pixel of the image, compositing the
+// drawn samples over the background.
+func (b *sampleBuffer) resolve(img *image.RGBA, samples int, background Color) {
+	bounds := img.Bounds()
+	weight := 1 / float64(samples*samples)
+
+	for py := range bounds.Dy() {
+		for px := range bounds.Dx() {
+			var r, g, bl, a float64
+
+			for sy := py * samples; sy < (py+1)*samples; sy++ {
+				for sx := px * samples; sx < (px+1)*samples; sx++ {
+					i := sy*b.width + sx
+
+					c := background
+					if !math.IsInf(b.depth[i], -1) {
+						c = compositeOver(b.color[i], background)
+					}
+
+					// Average premultiplied channels so transparent samples do not darken edges.
+					alpha := clampChannel(c.A)
+					r += clampChannel(c.R) * alpha
+					g += clampChannel(c.G) * alpha
+					bl += clampChannel(c.B) * alpha
+					a += alpha
+				}
+			}
+
+			img.SetRGBA(px, py, color.RGBA{R: channel8(r * weight), G: channel8(g * weight), B: channel8(bl * weight), A: channel8(a * weight)})
+		}
+	}
+}
+
+// channel8 converts a channel in [0, 1] to 8 bits.
+func channel8(v float64) uint8 {
+	return uint8(math.Round(clampChannel(v) * colorChannelMax))
+}
+
+// compositeOver returns top drawn over bottom using its alpha.
+func compositeOver(top, bottom Color) Color {
+	ta, ba := clampChannel(top.A), clampChannel(bottom.A)
+	a := ta + ba*(1-ta)
+
+	if a == 0 {
+		return Color{R: 0, G: 0, B: 0, A: 0}
+	}
+
+	mix := func(t, b float64) float64 {
+		return (t*ta + b*ba*(1-ta)) / a
+	}
+
+	return Color{R: mix(top.R, bottom.R), G: mix(top.G, bottom.G), B: mix(top.B, bottom.B), A: a}
+}
+
+// edgeFunction returns twice the signed area of the triangle (a, b, (x, y)).
+func edgeFunction(a, b Vector3, x, y float64) float64 {
+	return (b.X-a.X)*(y-a.Y) - (b.Y-a.Y)*(x-a.X)
+}
+
+// boundaryDistance returns the distance from (x, y) to the nearest edge of a polygon.
+func boundaryDistance(points []Vector3, x, y float64) float64 {
+	nearest := math.Inf(1)
+
+	for i, a := range points {
+		b := points[(i+1)%len(points)]
+		nearest = math.Min(nearest, segmentDistance(a, b, x, y))
+	}
+
+	return nearest
+}
+
+// segmentDistance returns the distance from (x, y) to the segment ab in the XY plane.
+func segmentDistance(a, b Vector3, x, y float64) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	t := 0.0
+
+	if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+		t = math.Max(0, math.Min(1, ((x-a.X)*dx+(y-a.Y)*dy)/lengthSq))
+	}
+
+	return math.Hypot(x-(a.X+t*dx), y-(a.Y+t*dy))
+}
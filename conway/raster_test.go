@@ -0,0 +1,108 @@
+package conway_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frontCamera looks straight at the +Z face of a seed.
+func frontCamera() conway.Camera {
+	return conway.Camera{Yaw: 0, Pitch: 0, Roll: 0, Projection: conway.Orthographic, Distance: 4, FieldOfView: 0.5}
+}
+
+func TestRenderImage(t *testing.T) {
+	t.Parallel()
+
+	img := conway.RenderImage(conway.Cube(), conway.WithSize(64, 48))
+
+	assert.Equal(t, 64, img.Bounds().Dx())
+	assert.Equal(t, 48, img.Bounds().Dy())
+	assert.Equal(t, uint8(0), img.RGBAAt(0, 0).A, "background is transparent by default")
+	assert.Equal(t, uint8(255), img.RGBAAt(32, 24).A, "the polyhedron covers the centre")
+}
+
+func TestRenderImageDepth(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+
+	for _, f := range p.Faces {
+		c := conway.RGB(0, 0, 1)
+		if f.Normal().Z > 0.5 {
+			c = conway.RGB(1, 0, 0)
+		}
+
+		conway.ColorAttribute().Set(f, c)
+	}
+
+	style := conway.DefaultStyle()
+	style.Stroke.A = 0
+	flat := conway.Light{Direction: conway.Vector3{X: 0, Y: 0, Z: 1}, Ambient: 1, Diffuse: 0}
+
+	for _, projection := range []conway.Projection{conway.Orthographic, conway.Perspective} {
+		cam := frontCamera()
+		cam.Projection = projection
+		cam.Yaw = 0.3
+
+		img := conway.RenderImage(p, conway.WithSize(100, 100), conway.WithCamera(cam), conway.WithStyle(style), conway.WithLight(flat))
+		center := img.RGBAAt(50, 50)
+
+		assert.Equal(t, uint8(255), center.R, "the front face is nearest at the centre")
+		assert.Equal(t, uint8(0), center.B)
+	}
+}
+
+func TestRenderImageAntialiasing(t *testing.T) {
+	t.Parallel()
+
+	partial := func(samples int) int {
+		img := conway.RenderImage(conway.Octahedron(), conway.WithSize(80, 80), conway.WithAntialiasing(samples))
+		count := 0
+
+		for y := range 80 {
+			for x := range 80 {
+				if a := img.RGBAAt(x, y).A; a > 0 && a < 255 {
+					count++
+				}
+			}
+		}
+
+		return count
+	}
+
+	assert.Zero(t, partial(1), "without anti-aliasing pixels are either covered or not")
+	assert.Positive(t, partial(4), "anti-aliasing blends the silhouette into the background")
+}
+
+func TestRenderImageBackground(t *testing.T) {
+	t.Parallel()
+
+	style := conway.DefaultStyle()
+	style.Background = conway.RGB(0, 1, 0)
+
+	img := conway.RenderImage(conway.Tetrahedron(), conway.WithSize(32, 32), conway.WithStyle(style))
+	corner := img.RGBAAt(0, 0)
+
+	assert.Equal(t, uint8(255), corner.G)
+	assert.Equal(t, uint8(255), corner.A)
+}
+
+func TestWritePNG(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, conway.WritePNG(&buf, conway.Dodecahedron(), conway.WithSize(40, 30)))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 40, img.Bounds().Dx())
+	assert.Equal(t, 30, img.Bounds().Dy())
+
+	assert.ErrorIs(t, conway.WritePNG(failingWriter{}, conway.Dodecahedron()), errWriteFailed)
+}
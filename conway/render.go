@@ -0,0 +1,328 @@
+package conway
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+const (
+	// defaultRenderSize is the default image width and height in pixels.
+	defaultRenderSize = 512
+	// defaultYaw and defaultPitch give a three-quarter view that shows several sides.
+	defaultYaw   = math.Pi / 6
+	defaultPitch = -math.Pi / 9
+	// defaultCameraDistance is the perspective camera distance in circumradii.
+	defaultCameraDistance = 4
+	// defaultFieldOfView is the perspective field of view in radians.
+	defaultFieldOfView = math.Pi / 6
+	// defaultRenderMargin is the fraction of the image left empty around the polyhedron.
+	defaultRenderMargin = 0.05
+	// defaultAmbient and defaultDiffuse weight the Lambert lighting terms.
+	defaultAmbient = 0.35
+	defaultDiffuse = 0.65
+	// defaultStrokeWidth is the default edge width in pixels.
+	defaultStrokeWidth = 1
+	// defaultFillGray is the grey level of faces without a colour attribute.
+	defaultFillGray = 0.8
+	// defaultStrokeGray is the grey level of edges.
+	defaultStrokeGray = 0.15
+	// defaultSupersampling is the default number of raster samples per pixel along each axis.
+	defaultSupersampling = 3
+)
+
+// Projection selects how the camera maps 3D points onto the image plane.
+type Projection int
+
+const (
+	// Orthographic projects along parallel rays, preserving relative sizes.
+	Orthographic Projection = iota
+	// Perspective projects through a point, so nearer parts appear larger.
+	Perspective
+)
+
+// Camera positions the viewer. The polyhedron is centred on its centroid, rotated
+// by Yaw about the vertical axis, then Pitch about the horizontal axis, then Roll
+// about the viewing axis, and viewed from the positive Z axis looking at the origin.
+type Camera struct {
+	Yaw, Pitch, Roll float64    // Rotation angles in radians
+	Projection       Projection // Orthographic or Perspective
+	Distance         float64    // Perspective camera distance in circumradii, greater than 1
+	FieldOfView      float64    // Perspective field of view in radians
+}
+
+// DefaultCamera returns a three-quarter orthographic view.
+func DefaultCamera() Camera {
+	return Camera{
+		Yaw:         defaultYaw,
+		Pitch:       defaultPitch,
+		Roll:        0,
+		Projection:  Orthographic,
+		Distance:    defaultCameraDistance,
+		FieldOfView: defaultFieldOfView,
+	}
+}
+
+// rotate applies the camera rotation to a vector.
+func (c Camera) rotate(v Vector3) Vector3 {
+	sy, cy := math.Sincos(c.Yaw)
+	v = Vector3{X: cy*v.X + sy*v.Z, Y: v.Y, Z: -sy*v.X + cy*v.Z}
+
+	sp, cp := math.Sincos(c.Pitch)
+	v = Vector3{X: v.X, Y: cp*v.Y - sp*v.Z, Z: sp*v.Y + cp*v.Z}
+
+	sr, cr := math.Sincos(c.Roll)
+
+	return Vector3{X: cr*v.X - sr*v.Y, Y: sr*v.X + cr*v.Y, Z: v.Z}
+}
+
+// Light is a directional light combined with ambient light using Lambert shading.
+type Light struct {
+	Direction Vector3 // Direction towards the light in view space; +Z points at the viewer
+	Ambient   float64 // Brightness of faces facing away from the light
+	Diffuse   float64 // Additional brightness of faces facing the light
+}
+
+// DefaultLight returns a light from the upper left, in front of the polyhedron.
+func DefaultLight() Light {
+	return Light{Direction: Vector3{X: -1, Y: 1, Z: 2}, Ambient: defaultAmbient, Diffuse: defaultDiffuse}
+}
+
+// shade returns the Lambert brightness of a surface with the given view-space normal.
+func (l Light) shade(normal Vector3) float64 {
+	return l.Ambient + l.Diffuse*math.Max(0, normal.Dot(l.Direction.Normalize()))
+}
+
+// Style sets how faces and edges are drawn.
+type Style struct {
+	Fill        Color   // Face colour when a face has no ColorAttribute
+	Stroke      Color   // Edge colour; a zero alpha draws no edges
+	StrokeWidth float64 // Edge width in pixels
+	Background  Color   // Image background; a zero alpha leaves it transparent
+}
+
+// DefaultStyle returns light grey faces with dark edges on a transparent background.
+func DefaultStyle() Style {
+	return Style{
+		Fill:        RGB(defaultFillGray, defaultFillGray, defaultFillGray),
+		Stroke:      RGB(defaultStrokeGray, defaultStrokeGray, defaultStrokeGray),
+		StrokeWidth: defaultStrokeWidth,
+		Background:  Color{R: 0, G: 0, B: 0, A: 0},
+	}
+}
+
+// RenderOption configures a renderer.
+type RenderOption func(*renderConfig)
+
+// renderConfig holds renderer settings.
+type renderConfig struct {
+	width, height int
+	camera        Camera
+	light         Light
+	style         Style
+	samples       int // Supersamples per pixel along each axis for raster output
+}
+
+// newRenderConfig applies options to the defaults.
+func newRenderConfig(opts []RenderOption) renderConfig {
+	cfg := renderConfig{
+		width:   defaultRenderSize,
+		height:  defaultRenderSize,
+		camera:  DefaultCamera(),
+		light:   DefaultLight(),
+		style:   DefaultStyle(),
+		samples: defaultSupersampling,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithSize sets the image size in pixels.
+func WithSize(width, height int) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.width = width
+		cfg.height = height
+	}
+}
+
+// WithCamera sets the camera.
+func WithCamera(camera Camera) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.camera = camera
+	}
+}
+
+// WithAntialiasing sets the number of samples taken per pixel along each axis by
+// raster renderers, smoothing polygon and edge boundaries. One sample disables
+// anti-aliasing. Vector output such as SVG is unaffected.
+func WithAntialiasing(samples int) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.samples = max(samples, 1)
+	}
+}
+
+// WithLight sets the lighting.
+func WithLight(light Light) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.light = light
+	}
+}
+
+// WithStyle sets the face and edge style.
+func WithStyle(style Style) RenderOption {
+	return func(cfg *renderConfig) {
+		cfg.style = style
+	}
+}
+
+// projectedFace is a visible face in image coordinates, ready to draw.
+type projectedFace struct {
+	id     int
+	points []Vector3 // Image x and y in pixels, with a depth in Z that is larger nearer the camera and linear across the image
+	depth  float64   // Mean view-space depth, used for painter's ordering
+	color  Color     // Shaded fill colour
+}
+
+// projectScene transforms, culls, shades and sorts the faces of a polyhedron.
+// Faces are returned back to front, so drawing them in order paints nearer faces last.
+func projectScene(p *Polyhedron, cfg renderConfig) []projectedFace {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	center := p.calculateCentroidUnsafe()
+	radius := 0.0
+
+	for _, v := range p.Vertices {
+		radius = math.Max(radius, v.Position.Distance(center))
+	}
+
+	if radius == 0 {
+		return nil
+	}
+
+	view := newViewTransform(cfg, center, radius)
+	colorKey := ColorAttribute()
+	faces := make([]projectedFace, 0, len(p.Faces))
+
+	for _, f := range sortedFaces(p) {
+		viewPoints := make([]Vector3, len(f.Vertices))
+		depth := 0.0
+
+		for i, v := range f.Vertices {
+			viewPoints[i] = view.toView(v.Position)
+			depth += viewPoints[i].Z
+		}
+
+		depth /= float64(len(viewPoints))
+
+		normal := view.camera.rotate(f.Normal())
+		if !view.facesViewer(normal, f.Centroid()) {
+			continue
+		}
+
+		base, ok := colorKey.Get(f)
+		if !ok {
+			base = cfg.style.Fill
+		}
+
+		points := make([]Vector3, len(viewPoints))
+
+		for i, vp := range viewPoints {
+			points[i] = view.toImage(vp)
+		}
+
+		faces = append(faces, projectedFace{id: f.ID, points: points, depth: depth, color: shadeColor(base, cfg.light.shade(normal))})
+	}
+
+	slices.SortStableFunc(faces, func(a, b projectedFace) int { return cmp.Compare(a.depth, b.depth) })
+
+	return faces
+}
+
+// outwardNormal returns the face normal, flipped if it points towards the centre of
+// the polyhedron, so nets and planar layouts can be built from faces wound
+// inconsistently.
+func outwardNormal(f *Face, center Vector3) Vector3 {
+	normal := f.Normal()
+
+	if normal.Dot(f.Centroid().Sub(center)) < 0 {
+		return normal.Scale(-1)
+	}
+
+	return normal
+}
+
+// shadeColor scales a colour's RGB channels by a brightness, keeping alpha.
+func shadeColor(c Color, brightness float64) Color {
+	return Color{
+		R: clampChannel(c.R * brightness),
+		G: clampChannel(c.G * brightness),
+		B: clampChannel(c.B * brightness),
+		A: c.A,
+	}
+}
+
+// viewTransform maps model coordinates to view space and image pixels.
+type viewTransform struct {
+	camera       Camera
+	center       Vector3
+	radius       float64
+	scale        float64 // Pixels per unit at the image plane
+	focal        float64 // Perspective focal length in units of radius
+	halfW, halfH float64
+}
+
+// newViewTransform fits the polyhedron's circumsphere into the image.
+func newViewTransform(cfg renderConfig, center Vector3, radius float64) viewTransform {
+	halfW, halfH := float64(cfg.width)/2, float64(cfg.height)/2
+	fit := math.Min(halfW, halfH) * (1 - defaultRenderMargin)
+
+	view := viewTransform{camera: cfg.camera, center: center, radius: radius, scale: fit, focal: 0, halfW: halfW, halfH: halfH}
+
+	if cfg.camera.Projection == Perspective {
+		// Scale so the circumsphere's silhouette just fits the image.
+		d := math.Max(cfg.camera.Distance, 1+defaultRenderMargin)
+		view.camera.Distance = d
+		view.focal = 1 / math.Tan(cfg.camera.FieldOfView/2)
+		view.scale = fit / (view.focal / math.Sqrt(d*d-1))
+	}
+
+	return view
+}
+
+// toView returns a point in view space, in units of the circumradius.
+func (t viewTransform) toView(pos Vector3) Vector3 {
+	return t.camera.rotate(pos.Sub(t.center).Scale(1 / t.radius))
+}
+
+// toImage projects a view-space point to pixels. Z holds the depth: the view-space
+// Z for orthographic projection, and the reciprocal distance from the camera plane
+// for perspective, which unlike Z itself interpolates linearly across the image.
+func (t viewTransform) toImage(v Vector3) Vector3 {
+	x, y, z := v.X, v.Y, v.Z
+
+	if t.camera.Projection == Perspective {
+		w := t.camera.Distance - v.Z
+		x = v.X * t.focal / w
+		y = v.Y * t.focal / w
+		z = 1 / w
+	}
+
+	return Vector3{X: t.halfW + x*t.scale, Y: t.halfH - y*t.scale, Z: z}
+}
+
+// facesViewer reports whether a face with the given view-space normal and model-space
+// centroid faces the camera.
+func (t viewTransform) facesViewer(normal, centroid Vector3) bool {
+	if t.camera.Projection == Perspective {
+		toCamera := Vector3{X: 0, Y: 0, Z: t.camera.Distance}.Sub(t.toView(centroid))
+
+		return normal.Dot(toCamera) > 0
+	}
+
+	return normal.Z > 0
+}
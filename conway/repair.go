@@ -0,0 +1,558 @@
+package conway
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// defaultPlanarizeIterations bounds the rounds of moving vertices towards the
+	// planes of their faces.
+	defaultPlanarizeIterations = 1000
+	// planarizeMargin is the fraction of the planarity tolerance that planarizing aims
+	// for, leaving room for the different plane ValidatePlanarity measures from.
+	planarizeMargin = 0.01
+)
+
+// ErrNothingLeft is returned by Repair when every face is degenerate.
+var ErrNothingLeft = errors.New("no faces remain after repair")
+
+// RepairOption configures Repair.
+type RepairOption func(*repairConfig)
+
+// repairConfig holds repair settings.
+type repairConfig struct {
+	tolerances ValidationTolerances
+	weld       float64 // Negative to use tolerances.EdgeLength
+	iterations int
+}
+
+func newRepairConfig(opts []RepairOption) repairConfig {
+	cfg := repairConfig{
+		tolerances: DefaultValidationTolerances(),
+		weld:       -1,
+		iterations: defaultPlanarizeIterations,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.weld < 0 {
+		cfg.weld = cfg.tolerances.EdgeLength
+	}
+
+	return cfg
+}
+
+// WithRepairTolerances sets the tolerances the repaired polyhedron should pass
+// Validate with: faces smaller than FaceArea are removed, vertices closer than
+// EdgeLength are welded and faces are planarized to well within Planarity. The
+// default is DefaultValidationTolerances.
+func WithRepairTolerances(tolerances ValidationTolerances) RepairOption {
+	return func(cfg *repairConfig) {
+		cfg.tolerances = tolerances
+	}
+}
+
+// WithWeldTolerance sets the distance within which vertices are merged, such as to
+// join the duplicated corners of an imported triangle soup. The default is the
+// EdgeLength tolerance.
+func WithWeldTolerance(distance float64) RepairOption {
+	return func(cfg *repairConfig) {
+		cfg.weld = distance
+	}
+}
+
+// WithPlanarizeIterations bounds the rounds of planarizing; zero leaves faces as they
+// are. The default is 1000.
+func WithPlanarizeIterations(iterations int) RepairOption {
+	return func(cfg *repairConfig) {
+		cfg.iterations = iterations
+	}
+}
+
+// RepairReport describes the changes made by Repair.
+type RepairReport struct {
+	MergedVertices  int // Vertices welded onto a coincident vertex
+	RemovedVertices int // Vertices left in no face once degenerate faces were removed
+	RemovedEdges    int // Edges whose ends were welded together
+	RemovedFaces    int // Faces with fewer than three distinct corners or too little area
+	FlippedFaces    int // Faces reversed to agree with their neighbours and face outwards
+	// NonOrientable is set when no choice of face directions traverses every edge
+	// once each way, as for a Möbius strip; the winding is then left partly
+	// inconsistent.
+	NonOrientable bool
+	// PlanarizedFaces counts faces that were not planar within tolerance.
+	PlanarizedFaces int
+	// MaxPlanarityError is the largest distance of a vertex from its face's plane after
+	// planarizing.
+	MaxPlanarityError float64
+}
+
+// Changed reports whether Repair changed anything.
+func (r *RepairReport) Changed() bool {
+	return r.MergedVertices+r.RemovedVertices+r.RemovedEdges+r.RemovedFaces+r.FlippedFaces+r.PlanarizedFaces > 0
+}
+
+// Repair returns a copy of the polyhedron with common defects fixed, and a report of
+// what changed. In order, it:
+//
+//   - welds vertices closer than the weld tolerance, collapsing the zero-length
+//     edges between them;
+//   - removes faces left with fewer than three distinct corners or with too little
+//     area, and any vertices no face uses;
+//   - orients faces consistently by propagating across shared edges, so every edge
+//     is traversed once in each direction, then turns each closed component to
+//     enclose positive volume, or negative volume if it is a cavity inside another,
+//     and each open one to agree with most of its input faces;
+//   - moves vertices towards the planes of their faces until every face is planar.
+//
+// Element IDs, provenance and attributes are not preserved.
+// Thread-safe for concurrent access.
+func Repair(p *Polyhedron, opts ...RepairOption) (*Polyhedron, *RepairReport, error) {
+	cfg := newRepairConfig(opts)
+	report := &RepairReport{
+		MergedVertices:    0,
+		RemovedVertices:   0,
+		RemovedEdges:      0,
+		RemovedFaces:      0,
+		FlippedFaces:      0,
+		NonOrientable:     false,
+		PlanarizedFaces:   0,
+		MaxPlanarityError: 0,
+	}
+
+	p.mu.RLock()
+
+	vertices := sortedVertices(p)
+	index := indexVertices(vertices)
+	positions := make([]Vector3, len(vertices))
+
+	for i, v := range vertices {
+		positions[i] = v.Position
+	}
+
+	welded := weldPositions(positions, cfg.weld)
+
+	for _, e := range p.Edges {
+		if welded[index[e.V1.ID]] == welded[index[e.V2.ID]] {
+			report.RemovedEdges++
+		}
+	}
+
+	var faces [][]int
+
+	for _, f := range sortedFaces(p) {
+		boundary := make([]int, 0, len(f.Vertices))
+
+		for _, v := range f.Vertices {
+			boundary = append(boundary, welded[index[v.ID]])
+		}
+
+		boundary = dropRepeatedCorners(boundary)
+
+		if len(boundary) < minDegree || polygonArea(positions, boundary) < cfg.tolerances.FaceArea {
+			report.RemovedFaces++
+
+			continue
+		}
+
+		faces = append(faces, boundary)
+	}
+
+	name := p.Name
+
+	p.mu.RUnlock()
+
+	for i, w := range welded {
+		if w != i {
+			report.MergedVertices++
+		}
+	}
+
+	if len(faces) == 0 {
+		return nil, report, fmt.Errorf("repairing %s: %w", name, ErrNothingLeft)
+	}
+
+	positions, faces, report.RemovedVertices = compactVertices(positions, faces)
+	report.RemovedVertices -= report.MergedVertices
+
+	flipped, orientable := orientFaces(positions, faces)
+	report.NonOrientable = !orientable
+
+	for i, flip := range flipped {
+		if flip {
+			reverseBoundary(faces[i])
+			report.FlippedFaces++
+		}
+	}
+
+	report.PlanarizedFaces, report.MaxPlanarityError = planarize(positions, faces,
+		cfg.tolerances.Planarity*planarizeMargin, cfg.iterations)
+
+	b := newMeshBuilder(0, 0)
+	b.positions = positions
+	b.faces = faces
+	b.wound = true
+
+	return b.build(name, 0), report, nil
+}
+
+// weldPositions maps each position to the index of the first position within
+// tolerance of it, using a grid of cells the size of the tolerance so only
+// neighbouring cells are searched.
+func weldPositions(positions []Vector3, tolerance float64) []int {
+	welded := make([]int, len(positions))
+	cells := make(map[[3]int64][]int)
+
+	cellOf := func(v Vector3) [3]int64 {
+		if tolerance <= 0 {
+			return [3]int64{int64(math.Float64bits(v.X)), int64(math.Float64bits(v.Y)), int64(math.Float64bits(v.Z))}
+		}
+
+		return [3]int64{int64(math.Floor(v.X / tolerance)), int64(math.Floor(v.Y / tolerance)), int64(math.Floor(v.Z / tolerance))}
+	}
+
+	for i, pos := range positions {
+		welded[i] = i
+		cell := cellOf(pos)
+
+		if tolerance > 0 {
+		search:
+			for dx := int64(-1); dx <= 1; dx++ {
+				for dy := int64(-1); dy <= 1; dy++ {
+					for dz := int64(-1); dz <= 1; dz++ {
+						for _, j := range cells[[3]int64{cell[0] + dx, cell[1] + dy, cell[2] + dz}] {
+							if positions[j].Distance(pos) <= tolerance {
+								welded[i] = j
+
+								break search
+							}
+						}
+					}
+				}
+			}
+		} else if others := cells[cell]; len(others) > 0 {
+			welded[i] = others[0]
+		}
+
+		if welded[i] == i {
+			cells[cell] = append(cells[cell], i)
+		}
+	}
+
+	return welded
+}
+
+// dropRepeatedCorners removes corners equal to the one before them, cyclically.
+func dropRepeatedCorners(boundary []int) []int {
+	kept := boundary[:0]
+
+	for i, v := range boundary {
+		if v != boundary[(i+len(boundary)-1)%len(boundary)] {
+			kept = append(kept, v)
+		}
+	}
+
+	if len(kept) == 0 && len(boundary) > 0 {
+		kept = append(kept, boundary[0]) // Every corner was the same vertex
+	}
+
+	return kept
+}
+
+// boundaryPoints returns the positions of a face's corners.
+func boundaryPoints(positions []Vector3, boundary []int) []Vector3 {
+	points := make([]Vector3, len(boundary))
+
+	for i, v := range boundary {
+		points[i] = positions[v]
+	}
+
+	return points
+}
+
+// polygonArea returns the area of a face given as vertex indices.
+func polygonArea(positions []Vector3, boundary []int) float64 {
+	return newellVector(boundaryPoints(positions, boundary)).Length() / 2
+}
+
+// compactVertices drops positions no face uses, renumbering the faces, and returns
+// the number dropped.
+func compactVertices(positions []Vector3, faces [][]int) ([]Vector3, [][]int, int) {
+	renumber := make([]int, len(positions))
+
+	for i := range renumber {
+		renumber[i] = -1
+	}
+
+	var kept []Vector3
+
+	for _, boundary := range faces {
+		for i, v := range boundary {
+			if renumber[v] < 0 {
+				renumber[v] = len(kept)
+				kept = append(kept, positions[v])
+			}
+
+			boundary[i] = renumber[v]
+		}
+	}
+
+	return kept, faces, len(positions) - len(kept)
+}
+
+// reverseBoundary reverses a face's direction in place.
+func reverseBoundary(boundary []int) {
+	for i, j := 0, len(boundary)-1; i < j; i, j = i+1, j-1 {
+		boundary[i], boundary[j] = boundary[j], boundary[i]
+	}
+}
+
+// orientFaces chooses which faces to reverse so that neighbouring faces traverse
+// their shared edges in opposite directions, propagating breadth first from one
+// face of each connected component. A closed component is then turned to enclose
+// positive volume, or negative volume if it is a cavity nested inside an odd number
+// of others, and an open one to reverse as few faces as possible. It reports false
+// if some edge could not be made consistent.
+func orientFaces(positions []Vector3, faces [][]int) ([]bool, bool) {
+	type edgeKey [2]int
+
+	key := func(a, b int) edgeKey { return edgeKey{min(a, b), max(a, b)} }
+	edgeFaces := make(map[edgeKey][]int)
+
+	for fi, boundary := range faces {
+		for i, v := range boundary {
+			k := key(v, boundary[(i+1)%len(boundary)])
+			edgeFaces[k] = append(edgeFaces[k], fi)
+		}
+	}
+
+	// traverses reports whether face fi, reversed if flip is set, runs from a to b.
+	traverses := func(fi, a, b int, flip bool) bool {
+		boundary := faces[fi]
+
+		for i, v := range boundary {
+			next := boundary[(i+1)%len(boundary)]
+
+			if v == a && next == b {
+				return !flip
+			}
+
+			if v == b && next == a {
+				return flip
+			}
+		}
+
+		return false
+	}
+
+	flipped := make([]bool, len(faces))
+	visited := make([]bool, len(faces))
+	orientable := true
+
+	var closedComponents [][]int
+
+	for start := range faces {
+		if visited[start] {
+			continue
+		}
+
+		visited[start] = true
+		component := []int{start}
+		closed := true
+
+		for queue := []int{start}; len(queue) > 0; queue = queue[1:] {
+			fi := queue[0]
+			boundary := faces[fi]
+
+			for i := range boundary {
+				a, b := boundary[i], boundary[(i+1)%len(boundary)]
+				if flipped[fi] {
+					a, b = b, a
+				}
+
+				neighbours := edgeFaces[key(a, b)]
+				if len(neighbours) != 2 {
+					closed = false
+				}
+
+				for _, gi := range neighbours {
+					if gi == fi {
+						continue
+					}
+
+					if !visited[gi] {
+						visited[gi] = true
+						flipped[gi] = traverses(gi, a, b, false)
+						component = append(component, gi)
+						queue = append(queue, gi)
+					} else if traverses(gi, a, b, flipped[gi]) {
+						orientable = false
+					}
+				}
+			}
+		}
+
+		if closed {
+			closedComponents = append(closedComponents, component)
+		} else if orientComponent(positions, faces, component, flipped, false) {
+			reverseComponent(component, flipped)
+		}
+	}
+
+	// Closed components nested inside an odd number of others are cavities, and
+	// are turned to enclose negative volume.
+	polygons := make([][][]Vector3, len(closedComponents))
+
+	for i, component := range closedComponents {
+		for _, fi := range component {
+			polygons[i] = append(polygons[i], boundaryPoints(positions, faces[fi]))
+		}
+	}
+
+	for i, depth := range nestingDepths(polygons) {
+		cavity := depth%2 == 1
+
+		if orientComponent(positions, faces, closedComponents[i], flipped, true) != cavity {
+			reverseComponent(closedComponents[i], flipped)
+		}
+	}
+
+	return flipped, orientable
+}
+
+// reverseComponent reverses the chosen direction of every face of a component.
+func reverseComponent(component []int, flipped []bool) {
+	for _, fi := range component {
+		flipped[fi] = !flipped[fi]
+	}
+}
+
+// orientComponent reports whether a consistently oriented component should be
+// reversed as a whole: a closed component if it encloses negative volume, an open one
+// if most of its faces were flipped.
+func orientComponent(positions []Vector3, faces [][]int, component []int, flipped []bool, closed bool) bool {
+	if !closed {
+		count := 0
+
+		for _, fi := range component {
+			if flipped[fi] {
+				count++
+			}
+		}
+
+		return 2*count > len(component)
+	}
+
+	var centre Vector3
+
+	corners := 0
+
+	for _, fi := range component {
+		for _, v := range faces[fi] {
+			centre = centre.Add(positions[v])
+			corners++
+		}
+	}
+
+	centre = centre.Scale(1 / float64(corners))
+	volume := 0.0
+
+	for _, fi := range component {
+		normal := newellVector(boundaryPoints(positions, faces[fi]))
+		if flipped[fi] {
+			normal = normal.Scale(-1)
+		}
+
+		// Each face contributes the cone from the centre: a third of its height times
+		// its area, with the Newell vector giving twice the area.
+		volume += normal.Dot(positions[faces[fi][0]].Sub(centre))
+	}
+
+	return volume < 0
+}
+
+// planarize moves vertices towards the best-fit planes of their faces, each by the
+// mean of the moves its faces ask for, until every face is within tolerance of its
+// plane or the iterations run out. It returns the number of faces that were not
+// planar to begin with and the largest distance remaining.
+func planarize(positions []Vector3, faces [][]int, tolerance float64, iterations int) (int, float64) {
+	deviation := func(boundary []int) (Vector3, Vector3, float64) {
+		points := boundaryPoints(positions, boundary)
+		normal := newellVector(points).Normalize()
+
+		var centre Vector3
+
+		for _, pt := range points {
+			centre = centre.Add(pt)
+		}
+
+		centre = centre.Scale(1 / float64(len(points)))
+		worst := 0.0
+
+		for _, pt := range points {
+			worst = math.Max(worst, math.Abs(normal.Dot(pt.Sub(centre))))
+		}
+
+		return normal, centre, worst
+	}
+
+	nonPlanar := 0
+	worst := 0.0
+
+	for _, boundary := range faces {
+		if len(boundary) <= minDegree {
+			continue
+		}
+
+		if _, _, d := deviation(boundary); d > tolerance {
+			nonPlanar++
+			worst = math.Max(worst, d)
+		}
+	}
+
+	moves := make([]Vector3, len(positions))
+	counts := make([]int, len(positions))
+
+	for range iterations {
+		if worst <= tolerance {
+			break
+		}
+
+		clear(moves)
+		clear(counts)
+
+		for _, boundary := range faces {
+			if len(boundary) <= minDegree {
+				continue
+			}
+
+			normal, centre, _ := deviation(boundary)
+
+			for _, v := range boundary {
+				moves[v] = moves[v].Sub(normal.Scale(normal.Dot(positions[v].Sub(centre))))
+				counts[v]++
+			}
+		}
+
+		for v, count := range counts {
+			if count > 0 {
+				positions[v] = positions[v].Add(moves[v].Scale(1 / float64(count)))
+			}
+		}
+
+		worst = 0
+
+		for _, boundary := range faces {
+			if len(boundary) > minDegree {
+				_, _, d := deviation(boundary)
+				worst = math.Max(worst, d)
+			}
+		}
+	}
+
+	return nonPlanar, worst
+}
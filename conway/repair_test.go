@@ -0,0 +1,194 @@
+package conway_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairUnchanged(t *testing.T) {
+	t.Parallel()
+
+	q, report, err := conway.Repair(conway.MustParse("tI"))
+	require.NoError(t, err)
+
+	assert.False(t, report.Changed())
+	assert.Equal(t, "tIcosahedron: V=60, E=90, F=32, χ=2", q.Stats())
+	assert.NoError(t, q.ValidateComplete())
+}
+
+func TestRepairTriangleSoup(t *testing.T) {
+	t.Parallel()
+
+	// A cube whose faces each have their own slightly perturbed corners, plus a
+	// sliver face collapsed onto one corner.
+	p := conway.NewPolyhedron("soup")
+	cube := conway.Cube()
+	jitter := 0.0
+
+	for _, f := range cube.Faces {
+		corners := make([]*conway.Vertex, len(f.Vertices))
+
+		for i, v := range f.Vertices {
+			jitter += 1e-9
+			corners[i] = p.AddVertex(v.Position.Add(conway.Vector3{X: jitter, Y: -jitter, Z: jitter}))
+		}
+
+		p.AddFace(corners)
+	}
+
+	corner := cube.Vertices[lowestVertexID(cube)].Position
+	p.AddFace([]*conway.Vertex{p.AddVertex(corner), p.AddVertex(corner), p.AddVertex(corner)})
+
+	q, report, err := conway.Repair(p, conway.WithWeldTolerance(1e-6))
+	require.NoError(t, err)
+
+	assert.Equal(t, 24+3-8, report.MergedVertices)
+	assert.Equal(t, 1, report.RemovedFaces)
+	assert.Equal(t, 3, report.RemovedEdges, "the sliver's edges collapse")
+	assert.Equal(t, 0, report.RemovedVertices)
+	assert.True(t, report.Changed())
+
+	assert.Len(t, q.Vertices, 8)
+	assert.Len(t, q.Edges, 12)
+	assert.Len(t, q.Faces, 6)
+	assert.True(t, q.Validate().Valid(), q.Validate().Err())
+
+	// Without welding, the corners stay apart and the faces are disconnected.
+	apart, _, err := conway.Repair(p)
+	require.NoError(t, err)
+	assert.Len(t, apart.Vertices, 24)
+}
+
+// inconsistentCube returns a cube whose top face is wound against its neighbours.
+// Unmarshalling links the faces as stored, without correcting their winding.
+func inconsistentCube(t *testing.T) *conway.Polyhedron {
+	t.Helper()
+
+	doc := `{
+		"version": 1,
+		"name": "cube",
+		"vertices": [
+			{"id": 1, "position": [-1, -1, -1]},
+			{"id": 2, "position": [1, -1, -1]},
+			{"id": 3, "position": [1, 1, -1]},
+			{"id": 4, "position": [-1, 1, -1]},
+			{"id": 5, "position": [-1, -1, 1]},
+			{"id": 6, "position": [1, -1, 1]},
+			{"id": 7, "position": [1, 1, 1]},
+			{"id": 8, "position": [-1, 1, 1]}
+		],
+		"faces": [
+			{"id": 10, "vertices": [0, 3, 2, 1]},
+			{"id": 11, "vertices": [7, 6, 5, 4]},
+			{"id": 12, "vertices": [0, 1, 5, 4]},
+			{"id": 13, "vertices": [2, 3, 7, 6]},
+			{"id": 14, "vertices": [0, 4, 7, 3]},
+			{"id": 15, "vertices": [1, 2, 6, 5]}
+		]
+	}`
+
+	var p conway.Polyhedron
+	require.NoError(t, json.Unmarshal([]byte(doc), &p))
+
+	return &p
+}
+
+func TestRepairWinding(t *testing.T) {
+	t.Parallel()
+
+	p := inconsistentCube(t)
+	require.Error(t, p.ValidateWinding())
+
+	q, report, err := conway.Repair(p)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.FlippedFaces)
+	assert.False(t, report.NonOrientable)
+	require.NoError(t, q.ValidateWinding())
+	assert.InDelta(t, 8.0, q.Volume(), 1e-9)
+}
+
+func TestRepairKeepsCavity(t *testing.T) {
+	t.Parallel()
+
+	shell, err := conway.Shell(conway.Octahedron(), 0.1)
+	require.NoError(t, err)
+
+	q, report, err := conway.Repair(shell)
+	require.NoError(t, err)
+
+	assert.Zero(t, report.FlippedFaces, "the cavity already faces the hollow")
+	assert.InDelta(t, shell.Volume(), q.Volume(), 1e-9)
+	require.NoError(t, q.ValidateComplete())
+}
+
+func TestRepairPlanarize(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("gC")
+	require.Error(t, p.ValidatePlanarity())
+
+	q, report, err := conway.Repair(p)
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, report.PlanarizedFaces)
+	assert.Less(t, report.MaxPlanarityError, 1e-10)
+	require.NoError(t, q.ValidateComplete())
+
+	// Without iterations the faces are only measured.
+	_, report, err = conway.Repair(p, conway.WithPlanarizeIterations(0))
+	require.NoError(t, err)
+	assert.Equal(t, 12, report.PlanarizedFaces)
+	assert.Greater(t, report.MaxPlanarityError, 1e-3)
+}
+
+func TestRepairNonOrientable(t *testing.T) {
+	t.Parallel()
+
+	// A Möbius strip of six quads, turning half a revolution as it goes round.
+	p := conway.NewPolyhedron("Möbius strip")
+	const n = 6
+
+	var top, bottom []*conway.Vertex
+
+	for i := range n {
+		theta := 2 * math.Pi * float64(i) / n
+		centre := conway.Vector3{X: 2 * math.Cos(theta), Y: 2 * math.Sin(theta), Z: 0}
+		across := conway.Vector3{X: math.Cos(theta/2) * math.Cos(theta), Y: math.Cos(theta/2) * math.Sin(theta), Z: math.Sin(theta / 2)}
+
+		top = append(top, p.AddVertex(centre.Add(across.Scale(0.5))))
+		bottom = append(bottom, p.AddVertex(centre.Sub(across.Scale(0.5))))
+	}
+
+	for i := range n - 1 {
+		p.AddFace([]*conway.Vertex{top[i], bottom[i], bottom[i+1], top[i+1]})
+	}
+
+	p.AddFace([]*conway.Vertex{top[n-1], bottom[n-1], top[0], bottom[0]})
+
+	q, report, err := conway.Repair(p, conway.WithPlanarizeIterations(0))
+	require.NoError(t, err)
+
+	assert.True(t, report.NonOrientable)
+	assert.Len(t, q.Faces, n)
+}
+
+func TestRepairNothingLeft(t *testing.T) {
+	t.Parallel()
+
+	p := conway.NewPolyhedron("sliver")
+	p.AddFace([]*conway.Vertex{
+		p.AddVertex(conway.Vector3{X: 0, Y: 0, Z: 0}),
+		p.AddVertex(conway.Vector3{X: 1, Y: 0, Z: 0}),
+		p.AddVertex(conway.Vector3{X: 2, Y: 0, Z: 0}),
+	})
+
+	_, report, err := conway.Repair(p)
+	require.ErrorIs(t, err, conway.ErrNothingLeft)
+	assert.Equal(t, 1, report.RemovedFaces)
+}
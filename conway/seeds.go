@@ -188,6 +188,16 @@ func Icosahedron() *Polyhedron {
 	return p
 }
 
+// isSeedSymbol reports whether symbol names one of the seed polyhedra.
+func isSeedSymbol(symbol string) bool {
+	switch symbol {
+	case "T", "C", "O", "D", "I":
+		return true
+	default:
+		return false
+	}
+}
+
 func GetSeed(symbol string) *Polyhedron {
 	switch symbol {
 	case "T":
@@ -0,0 +1,339 @@
+package conway
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// solidPlanarityTolerance is the largest distance of a quadrilateral's fourth vertex
+	// from the plane of the other three for Struts and Shell to keep it whole; beyond
+	// it the face is split into triangles. It is tighter than ValidatePlanarity's.
+	solidPlanarityTolerance = 1e-11
+	// offsetPlaneTolerance is the largest residual at which the offset planes of a
+	// vertex's faces are taken to meet in a single point.
+	offsetPlaneTolerance = 1e-9
+	// minOffsetDeterminant is the smallest determinant of the normal equations for which
+	// the offset planes are intersected rather than averaged.
+	minOffsetDeterminant = 1e-12
+)
+
+// Static errors for err113 compliance.
+var (
+	ErrInvalidThickness = errors.New("solid thickness must be positive")
+	ErrTooThick         = errors.New("solid is too thick for the polyhedron")
+	ErrOpenSurface      = errors.New("polyhedron surface is not closed")
+)
+
+// solidifier collects the output of Struts and Shell. Positions start with the input
+// vertices followed by their inward offsets; faces are wound outwards from the solid.
+type solidifier struct {
+	vertices  []*Vertex
+	faces     []*Face
+	index     map[int]int // Input vertex ID to position index
+	positions []Vector3
+	polygons  [][]int
+}
+
+// newSolidifier prepares to solidify a polyhedron whose inner surface lies depth
+// below its faces. The caller holds the read lock.
+func newSolidifier(p *Polyhedron, depth float64) *solidifier {
+	vertices := sortedVertices(p)
+	s := &solidifier{
+		vertices:  vertices,
+		faces:     sortedFaces(p),
+		index:     indexVertices(vertices),
+		positions: make([]Vector3, 2*len(vertices)),
+		polygons:  nil,
+	}
+
+	for i, v := range vertices {
+		s.positions[i] = v.Position
+		s.positions[len(vertices)+i] = offsetPosition(v, depth)
+	}
+
+	return s
+}
+
+// inner returns the position index of a vertex's inward offset.
+func (s *solidifier) inner(v *Vertex) int {
+	return len(s.vertices) + s.index[v.ID]
+}
+
+// addPoint appends a position and returns its index.
+func (s *solidifier) addPoint(pos Vector3) int {
+	s.positions = append(s.positions, pos)
+
+	return len(s.positions) - 1
+}
+
+// addPolygon appends a face, split into a fan of triangles if it is not planar.
+func (s *solidifier) addPolygon(indices ...int) {
+	if len(indices) > 3 && !s.planar(indices) {
+		for i := 1; i+1 < len(indices); i++ {
+			s.polygons = append(s.polygons, []int{indices[0], indices[i], indices[i+1]})
+		}
+
+		return
+	}
+
+	s.polygons = append(s.polygons, indices)
+}
+
+// planar reports whether every vertex of a polygon lies in the plane of its first three.
+func (s *solidifier) planar(indices []int) bool {
+	origin := s.positions[indices[0]]
+	normal := s.positions[indices[1]].Sub(origin).Cross(s.positions[indices[2]].Sub(origin)).Normalize()
+
+	for _, idx := range indices[3:] {
+		if math.Abs(normal.Dot(s.positions[idx].Sub(origin))) > solidPlanarityTolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// build assembles the collected faces, keeping their winding.
+func (s *solidifier) build(name string) *Polyhedron {
+	b := newMeshBuilder(0, 0)
+	b.positions = s.positions
+	b.faces = s.polygons
+	b.wound = true
+
+	return b.build(name, 0)
+}
+
+// offsetPosition returns the point depth below a vertex: where the planes of its
+// faces, moved inwards by depth, meet. When they do not meet in a single point, as at
+// irregular vertices of degree four or more, the vertex moves against its mean face
+// normal by depth on average.
+func offsetPosition(v *Vertex, depth float64) Vector3 {
+	normals := make([]Vector3, 0, len(v.Faces))
+
+	for _, f := range v.Faces {
+		normals = append(normals, f.Normal())
+	}
+
+	if direction, ok := planeIntersection(normals); ok {
+		return v.Position.Sub(direction.Scale(depth))
+	}
+
+	mean := Vector3{X: 0, Y: 0, Z: 0}
+
+	for _, n := range normals {
+		mean = mean.Add(n)
+	}
+
+	mean = mean.Normalize()
+	cos := 0.0
+
+	for _, n := range normals {
+		cos += n.Dot(mean) / float64(len(normals))
+	}
+
+	if cos <= 0 {
+		return v.Position.Sub(mean.Scale(depth))
+	}
+
+	return v.Position.Sub(mean.Scale(depth / cos))
+}
+
+// planeIntersection returns the least-squares solution x of n·x = 1 for every normal,
+// and whether it satisfies all of them: the displacement of unit depth into every face.
+func planeIntersection(normals []Vector3) (Vector3, bool) {
+	var rows [3]Vector3
+
+	sum := Vector3{X: 0, Y: 0, Z: 0}
+
+	for _, n := range normals {
+		rows[0] = rows[0].Add(n.Scale(n.X))
+		rows[1] = rows[1].Add(n.Scale(n.Y))
+		rows[2] = rows[2].Add(n.Scale(n.Z))
+		sum = sum.Add(n)
+	}
+
+	// The normal equations are symmetric, so rows double as columns for Cramer's rule.
+	det := rows[0].Dot(rows[1].Cross(rows[2]))
+	if math.Abs(det) < minOffsetDeterminant {
+		return Vector3{X: 0, Y: 0, Z: 0}, false
+	}
+
+	x := Vector3{
+		X: sum.Dot(rows[1].Cross(rows[2])) / det,
+		Y: rows[0].Dot(sum.Cross(rows[2])) / det,
+		Z: rows[0].Dot(rows[1].Cross(sum)) / det,
+	}
+
+	for _, n := range normals {
+		if math.Abs(n.Dot(x)-1) > offsetPlaneTolerance {
+			return x, false
+		}
+	}
+
+	return x, true
+}
+
+// checkSolidInput checks that a polyhedron can be solidified: it must be closed and
+// wound outwards.
+func checkSolidInput(p *Polyhedron) error {
+	p.mu.RLock()
+
+	for _, e := range p.Edges {
+		if len(e.Faces) != 2 {
+			p.mu.RUnlock()
+
+			return fmt.Errorf("edge %d has %d faces: %w", e.ID, len(e.Faces), ErrOpenSurface)
+		}
+	}
+
+	p.mu.RUnlock()
+
+	if err := p.ValidateWinding(); err != nil {
+		return fmt.Errorf("solidifying %s: %w", p.Name, err)
+	}
+
+	return nil
+}
+
+// Struts turns the polyhedron into a printable wireframe. Every edge becomes a
+// prismatic strut following the surface and depth deep, and the struts meet in solid
+// hubs at the vertices. Each face keeps an opening inset from its edges by half the
+// width, so the result is a closed surface with a tunnel through every face, whose
+// Euler characteristic is 4 minus twice the number of faces.
+//
+// The polyhedron must be closed and wound outwards. ErrTooThick is returned if an
+// opening or the inner side of a strut would collapse.
+func Struts(p *Polyhedron, width, depth float64) (*Polyhedron, error) {
+	if width <= 0 || depth <= 0 {
+		return nil, fmt.Errorf("struts of width %g and depth %g: %w", width, depth, ErrInvalidThickness)
+	}
+
+	if err := checkSolidInput(p); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	s := newSolidifier(p, depth)
+
+	for _, f := range s.faces {
+		if err := s.addStrutFace(f, width/2, depth); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.build(p.Name + " struts"), nil
+}
+
+// addStrutFace adds the strips of the struts around one face, its inner counterpart
+// and the walls of the opening between them.
+func (s *solidifier) addStrutFace(f *Face, inset, depth float64) error {
+	n := len(f.Vertices)
+	normal := f.Normal()
+
+	// inward[i] is the in-plane unit normal of edge i, pointing into the face.
+	inward := make([]Vector3, n)
+
+	for i := range n {
+		edge := f.Vertices[(i+1)%n].Position.Sub(f.Vertices[i].Position)
+		inward[i] = normal.Cross(edge).Normalize()
+	}
+
+	// The opening's corners lie inset from both edges meeting at each vertex.
+	opening := make([]Vector3, n)
+
+	for i := range n {
+		prev, next := inward[(i+n-1)%n], inward[i]
+
+		denominator := 1 + prev.Dot(next)
+		if denominator < offsetPlaneTolerance {
+			return fmt.Errorf("face %d: %w", f.ID, ErrTooThick)
+		}
+
+		opening[i] = f.Vertices[i].Position.Add(prev.Add(next).Scale(inset / denominator))
+	}
+
+	outer := make([]int, n)
+	inner := make([]int, n)
+
+	for i := range n {
+		j := (i + 1) % n
+		edge := f.Vertices[j].Position.Sub(f.Vertices[i].Position)
+
+		if opening[j].Sub(opening[i]).Dot(edge) <= 0 {
+			return fmt.Errorf("opening of face %d: %w", f.ID, ErrTooThick)
+		}
+
+		bottom := opening[i].Sub(normal.Scale(depth))
+		hub := s.positions[s.inner(f.Vertices[i])]
+
+		if bottom.Sub(hub).Dot(inward[i]) <= 0 || bottom.Sub(hub).Dot(inward[(i+n-1)%n]) <= 0 {
+			return fmt.Errorf("struts of face %d: %w", f.ID, ErrTooThick)
+		}
+
+		outer[i] = s.addPoint(opening[i])
+		inner[i] = s.addPoint(bottom)
+	}
+
+	for i := range n {
+		j := (i + 1) % n
+		a, b := s.index[f.Vertices[i].ID], s.index[f.Vertices[j].ID]
+		innerA, innerB := s.inner(f.Vertices[i]), s.inner(f.Vertices[j])
+
+		s.addPolygon(a, b, outer[j], outer[i])
+		s.addPolygon(innerB, innerA, inner[i], inner[j])
+		s.addPolygon(outer[i], outer[j], inner[j], inner[i])
+	}
+
+	return nil
+}
+
+// Shell hollows the polyhedron into a shell of the given wall thickness: the
+// original faces enclose an inner surface offset inwards by thickness and wound
+// towards the hollow. The result has two closed components.
+//
+// The polyhedron must be closed and wound outwards. ErrTooThick is returned if an
+// inner face would collapse or turn over.
+func Shell(p *Polyhedron, thickness float64) (*Polyhedron, error) {
+	if thickness <= 0 {
+		return nil, fmt.Errorf("shell of thickness %g: %w", thickness, ErrInvalidThickness)
+	}
+
+	if err := checkSolidInput(p); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	s := newSolidifier(p, thickness)
+
+	for _, f := range s.faces {
+		n := len(f.Vertices)
+		outer := make([]int, n)
+		inner := make([]int, n)
+
+		for i, v := range f.Vertices {
+			outer[i] = s.index[v.ID]
+			inner[n-1-i] = s.inner(v)
+		}
+
+		for i := range n {
+			j := (i + 1) % n
+			edge := f.Vertices[j].Position.Sub(f.Vertices[i].Position)
+			offsetEdge := s.positions[s.inner(f.Vertices[j])].Sub(s.positions[s.inner(f.Vertices[i])])
+
+			if offsetEdge.Dot(edge) <= 0 {
+				return nil, fmt.Errorf("inner face of face %d: %w", f.ID, ErrTooThick)
+			}
+		}
+
+		s.addPolygon(outer...)
+		s.addPolygon(inner...)
+	}
+
+	return s.build(p.Name + " shell"), nil
+}
@@ -0,0 +1,122 @@
+package conway_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStruts(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "C", "D", "tI", "ktI", "tkC"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(notation)
+
+			// Struts a quarter of the shortest edge wide leave every face an opening.
+			width := math.Inf(1)
+
+			for _, e := range p.Edges {
+				width = math.Min(width, e.Length()/4)
+			}
+
+			solid, err := conway.Struts(p, width, width/4)
+			require.NoError(t, err)
+
+			require.NoError(t, solid.ValidateComplete())
+			assert.Equal(t, 4-2*len(p.Faces), solid.EulerCharacteristic(), "one tunnel per face")
+
+			for _, e := range solid.Edges {
+				assert.Len(t, e.Faces, 2)
+			}
+		})
+	}
+}
+
+func TestShell(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "C", "I", "tI", "dtO", "tkC"} {
+		t.Run(notation, func(t *testing.T) {
+			t.Parallel()
+
+			p := conway.MustParse(notation)
+			solid, err := conway.Shell(p, 0.1)
+			require.NoError(t, err)
+
+			require.NoError(t, solid.ValidateComplete())
+			assert.Equal(t, 4, solid.EulerCharacteristic(), "two nested spheres")
+			assert.Len(t, solid.Vertices, 2*len(p.Vertices))
+		})
+	}
+}
+
+func TestShellClone(t *testing.T) {
+	t.Parallel()
+
+	shell, err := conway.Shell(conway.Octahedron(), 0.1)
+	require.NoError(t, err)
+
+	// The cavity's faces point into the hollow and must keep doing so.
+	clone := shell.Clone()
+	assert.InDelta(t, shell.Volume(), clone.Volume(), 1e-12)
+	assert.Less(t, clone.Volume(), conway.Octahedron().Volume())
+	require.NoError(t, clone.ValidateComplete())
+}
+
+func TestShellThickness(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+	solid, err := conway.Shell(p, 0.1)
+	require.NoError(t, err)
+
+	// A cube's corners move diagonally, keeping every inner face parallel to its outer
+	// face and the wall thickness away.
+	for _, f := range p.Faces {
+		n := f.Normal()
+		offset := n.Dot(f.Centroid())
+
+		found := false
+
+		for _, g := range solid.Faces {
+			if g.Normal().Dot(n) < -1+1e-9 {
+				if depth := offset - n.Dot(g.Centroid()); depth > 0 && depth < 0.5 {
+					assert.InDelta(t, 0.1, depth, 1e-9)
+
+					found = true
+				}
+			}
+		}
+
+		assert.True(t, found, "face %d has an inner counterpart", f.ID)
+	}
+}
+
+func TestSolidErrors(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+
+	_, err := conway.Struts(p, 0, 0.1)
+	require.ErrorIs(t, err, conway.ErrInvalidThickness)
+
+	_, err = conway.Shell(p, -1)
+	require.ErrorIs(t, err, conway.ErrInvalidThickness)
+
+	_, err = conway.Struts(p, 10, 0.1)
+	require.ErrorIs(t, err, conway.ErrTooThick)
+
+	_, err = conway.Shell(p, 10)
+	require.ErrorIs(t, err, conway.ErrTooThick)
+
+	p.RemoveFace(p.Faces[lowestFaceID(p)])
+
+	_, err = conway.Shell(p, 0.1)
+	require.ErrorIs(t, err, conway.ErrOpenSurface)
+}
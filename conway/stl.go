@@ -0,0 +1,72 @@
+package conway
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	// stlHeaderSize is the length of a binary STL file's free-form header.
+	stlHeaderSize = 80
+	// stlTriangleSize is the length of a binary STL triangle record: a normal, three
+	// vertices and a two-byte attribute count.
+	stlTriangleSize = 50
+	// stlVectorSize is the length of one three-component float32 vector.
+	stlVectorSize = 12
+)
+
+// WriteSTL writes the polyhedron as a binary STL file, the common exchange format for
+// 3D printing. Faces are triangulated by ear clipping in their own winding order,
+// which should be outwards as checked by ValidateWinding, and each triangle carries
+// its face's unit normal. Coordinates are written unscaled; slicers usually read them as
+// millimetres.
+func WriteSTL(w io.Writer, p *Polyhedron) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	faces := sortedFaces(p)
+	count := 0
+
+	for _, f := range faces {
+		count += max(len(f.Vertices)-2, 0)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	// The header must not start with "solid", which marks the text format.
+	header := make([]byte, stlHeaderSize)
+	copy(header, "binary STL: "+p.Name)
+	bw.Write(header) // Writes to the buffered writer cannot fail until Flush
+
+	var record [stlTriangleSize]byte
+
+	binary.LittleEndian.PutUint32(record[:], uint32(count))
+	bw.Write(record[:4])
+
+	for _, f := range faces {
+		putSTLVector(record[:], f.Normal())
+
+		for _, tri := range f.Triangulate(EarClipping) {
+			putSTLVector(record[stlVectorSize:], f.Vertices[tri[0]].Position)
+			putSTLVector(record[2*stlVectorSize:], f.Vertices[tri[1]].Position)
+			putSTLVector(record[3*stlVectorSize:], f.Vertices[tri[2]].Position)
+			bw.Write(record[:])
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing STL: %w", err)
+	}
+
+	return nil
+}
+
+// putSTLVector stores a vector as three little-endian float32 values.
+func putSTLVector(b []byte, v Vector3) {
+	binary.LittleEndian.PutUint32(b, math.Float32bits(float32(v.X)))
+	binary.LittleEndian.PutUint32(b[4:], math.Float32bits(float32(v.Y)))
+	binary.LittleEndian.PutUint32(b[8:], math.Float32bits(float32(v.Z)))
+}
@@ -0,0 +1,113 @@
+package conway_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stlTriangle is a binary STL triangle record.
+type stlTriangle struct {
+	Normal    [3]float32
+	Vertices  [3][3]float32
+	Attribute uint16
+}
+
+func readSTL(t *testing.T, p *conway.Polyhedron) []stlTriangle {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, conway.WriteSTL(&buf, p))
+
+	data := buf.Bytes()
+	require.GreaterOrEqual(t, len(data), 84)
+	assert.NotEqual(t, "solid", string(data[:5]), "binary STL headers must not look like text STL")
+
+	count := binary.LittleEndian.Uint32(data[80:84])
+	require.Len(t, data, 84+50*int(count))
+
+	triangles := make([]stlTriangle, count)
+	require.NoError(t, binary.Read(bytes.NewReader(data[84:]), binary.LittleEndian, triangles))
+
+	return triangles
+}
+
+func TestWriteSTL(t *testing.T) {
+	t.Parallel()
+
+	triangles := readSTL(t, conway.MustParse("tC"))
+	assert.Len(t, triangles, 8*1+6*6)
+
+	for _, tri := range triangles {
+		n := conway.Vector3{X: float64(tri.Normal[0]), Y: float64(tri.Normal[1]), Z: float64(tri.Normal[2])}
+		assert.InDelta(t, 1, n.Length(), 1e-6)
+		assert.Zero(t, tri.Attribute)
+	}
+}
+
+func TestWriteSTLStruts(t *testing.T) {
+	t.Parallel()
+
+	solid, err := conway.Struts(conway.Dodecahedron(), 0.1, 0.03)
+	require.NoError(t, err)
+
+	triangles := readSTL(t, solid)
+
+	// The triangles of a printable solid pair up along every edge in opposite
+	// directions and enclose a positive volume.
+	type point [3]float32
+
+	directed := make(map[[2]point]int)
+	volume := 0.0
+
+	for _, tri := range triangles {
+		for i := range 3 {
+			directed[[2]point{tri.Vertices[i], tri.Vertices[(i+1)%3]}]++
+		}
+
+		a, b, c := stlVector(tri.Vertices[0]), stlVector(tri.Vertices[1]), stlVector(tri.Vertices[2])
+		volume += a.Dot(b.Cross(c)) / 6
+
+		// Stored normals agree with the winding.
+		normal := b.Sub(a).Cross(c.Sub(a)).Normalize()
+		assert.InDelta(t, 1, normal.Dot(stlVector(tri.Normal)), 1e-4)
+	}
+
+	for edge, count := range directed {
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 1, directed[[2]point{edge[1], edge[0]}])
+	}
+
+	assert.Positive(t, volume)
+	assert.Less(t, volume, math.Abs(volumeOf(t, conway.Dodecahedron())))
+}
+
+func TestWriteSTLError(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, conway.WriteSTL(failingWriter{}, conway.Cube()), errWriteFailed)
+}
+
+func stlVector(v [3]float32) conway.Vector3 {
+	return conway.Vector3{X: float64(v[0]), Y: float64(v[1]), Z: float64(v[2])}
+}
+
+// volumeOf returns the volume enclosed by a polyhedron's STL triangles.
+func volumeOf(t *testing.T, p *conway.Polyhedron) float64 {
+	t.Helper()
+
+	volume := 0.0
+
+	for _, tri := range readSTL(t, p) {
+		a, b, c := stlVector(tri.Vertices[0]), stlVector(tri.Vertices[1]), stlVector(tri.Vertices[2])
+		volume += a.Dot(b.Cross(c)) / 6
+	}
+
+	return volume
+}
@@ -0,0 +1,110 @@
+package conway
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// svgCoordinatePrecision is the number of decimals written for coordinates.
+	svgCoordinatePrecision = 2
+	// svgOpacityPrecision is the number of decimals written for opacities.
+	svgOpacityPrecision = 3
+)
+
+// WriteSVG renders the polyhedron as an SVG image with flat-shaded faces.
+// Faces pointing away from the camera are culled and the rest are drawn back to
+// front (painter's algorithm). Each face is filled with its ColorAttribute, or the
+// style's fill colour, shaded by the light. Faces are taken to point the way they
+// are wound, so faces wound inwards, as reported by ValidateWinding, are culled.
+func WriteSVG(w io.Writer, p *Polyhedron, opts ...RenderOption) error {
+	cfg := newRenderConfig(opts)
+	faces := projectScene(p, cfg)
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		cfg.width, cfg.height, cfg.width, cfg.height)
+
+	bw.WriteString("<title>")
+	_ = xml.EscapeText(bw, []byte(p.Name)) // Writes to the buffered writer cannot fail until Flush
+	bw.WriteString("</title>\n")
+
+	if bg := cfg.style.Background; bg.A > 0 {
+		fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"%s/>`+"\n", bg.Hex(), svgOpacity("fill-opacity", bg.A))
+	}
+
+	stroke := `stroke="none"`
+	if s := cfg.style.Stroke; s.A > 0 && cfg.style.StrokeWidth > 0 {
+		stroke = fmt.Sprintf(`stroke="%s" stroke-width="%s" stroke-linejoin="round"%s`,
+			s.Hex(), svgNumber(cfg.style.StrokeWidth), svgOpacity("stroke-opacity", s.A))
+	}
+
+	fmt.Fprintf(bw, "<g %s>\n", stroke)
+
+	for _, f := range faces {
+		fmt.Fprintf(bw, `<polygon points="%s" fill="%s"%s/>`+"\n", svgPoints(f.points), f.color.Hex(), svgOpacity("fill-opacity", f.color.A))
+	}
+
+	bw.WriteString("</g>\n</svg>\n")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing SVG: %w", err)
+	}
+
+	return nil
+}
+
+// RenderSVG returns the SVG image produced by WriteSVG.
+func RenderSVG(p *Polyhedron, opts ...RenderOption) string {
+	var sb strings.Builder
+
+	_ = WriteSVG(&sb, p, opts...) // strings.Builder never returns an error
+
+	return sb.String()
+}
+
+// svgPoints formats polygon points as "x,y x,y ...".
+func svgPoints(points []Vector3) string {
+	parts := make([]string, len(points))
+
+	for i, pt := range points {
+		parts[i] = svgNumber(pt.X) + "," + svgNumber(pt.Y)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// svgNumber formats a coordinate compactly.
+func svgNumber(v float64) string {
+	return formatDecimal(v, svgCoordinatePrecision)
+}
+
+// formatDecimal formats a number with at most the given number of decimals, dropping
+// trailing zeros.
+func formatDecimal(v float64, precision int) string {
+	s := strconv.FormatFloat(v, 'f', precision, 64)
+
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	if s == "-0" || s == "" {
+		return "0"
+	}
+
+	return s
+}
+
+// svgOpacity returns an opacity attribute, or nothing for fully opaque colours.
+func svgOpacity(attribute string, alpha float64) string {
+	if alpha >= 1 {
+		return ""
+	}
+
+	return fmt.Sprintf(` %s="%s"`, attribute, strconv.FormatFloat(clampChannel(alpha), 'f', svgOpacityPrecision, 64))
+}
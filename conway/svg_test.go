@@ -0,0 +1,128 @@
+package conway_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// svgDocument is the subset of the SVG output inspected by the tests.
+type svgDocument struct {
+	Width  string `xml:"width,attr"`
+	Height string `xml:"height,attr"`
+	Title  string `xml:"title"`
+	Group  struct {
+		Stroke   string `xml:"stroke,attr"`
+		Polygons []struct {
+			Points string `xml:"points,attr"`
+			Fill   string `xml:"fill,attr"`
+		} `xml:"polygon"`
+	} `xml:"g"`
+}
+
+func parseSVG(t *testing.T, svg string) svgDocument {
+	t.Helper()
+
+	var doc svgDocument
+
+	require.NoError(t, xml.Unmarshal([]byte(svg), &doc))
+
+	return doc
+}
+
+func TestRenderSVG(t *testing.T) {
+	t.Parallel()
+
+	doc := parseSVG(t, conway.RenderSVG(conway.Cube()))
+
+	assert.Equal(t, "512", doc.Width)
+	assert.Equal(t, "512", doc.Height)
+	assert.Equal(t, "Cube", doc.Title)
+	assert.Len(t, doc.Group.Polygons, 3, "a three-quarter view shows three faces of a cube")
+
+	for _, poly := range doc.Group.Polygons {
+		assert.Len(t, strings.Fields(poly.Points), 4)
+	}
+}
+
+func TestRenderSVGCulling(t *testing.T) {
+	t.Parallel()
+
+	front := conway.Camera{Yaw: 0, Pitch: 0, Roll: 0, Projection: conway.Orthographic, Distance: 4, FieldOfView: 0.5}
+	doc := parseSVG(t, conway.RenderSVG(conway.Cube(), conway.WithCamera(front)))
+
+	assert.Len(t, doc.Group.Polygons, 1, "a face-on view shows only the front face")
+
+	// A convex polyhedron shows roughly half its faces from any direction.
+	doc = parseSVG(t, conway.RenderSVG(conway.MustParse("tI")))
+	assert.Greater(t, len(doc.Group.Polygons), 10)
+	assert.Less(t, len(doc.Group.Polygons), 32)
+}
+
+func TestRenderSVGPerspective(t *testing.T) {
+	t.Parallel()
+
+	cam := conway.DefaultCamera()
+	cam.Projection = conway.Perspective
+
+	doc := parseSVG(t, conway.RenderSVG(conway.Dodecahedron(), conway.WithCamera(cam), conway.WithSize(300, 200)))
+
+	assert.Equal(t, "300", doc.Width)
+	assert.Equal(t, "200", doc.Height)
+	assert.NotEmpty(t, doc.Group.Polygons)
+
+	// A perspective camera sees less than half the surface.
+	assert.LessOrEqual(t, len(doc.Group.Polygons), 6)
+}
+
+func TestRenderSVGColors(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+
+	for _, f := range p.Faces {
+		conway.ColorAttribute().Set(f, conway.RGB(1, 0, 0))
+	}
+
+	flat := conway.Light{Direction: conway.Vector3{X: 0, Y: 0, Z: 1}, Ambient: 1, Diffuse: 0}
+	style := conway.DefaultStyle()
+	style.Stroke = conway.Color{R: 0, G: 0, B: 0, A: 0}
+
+	doc := parseSVG(t, conway.RenderSVG(p, conway.WithLight(flat), conway.WithStyle(style)))
+
+	require.NotEmpty(t, doc.Group.Polygons)
+	assert.Equal(t, "none", doc.Group.Stroke)
+
+	for _, poly := range doc.Group.Polygons {
+		assert.Equal(t, "#ff0000", poly.Fill)
+	}
+}
+
+func TestRenderSVGDeterministic(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, conway.RenderSVG(conway.MustParse("tkC")), conway.RenderSVG(conway.MustParse("tkC")))
+}
+
+var errWriteFailed = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWriteFailed }
+
+func TestWriteSVG(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, conway.WriteSVG(&buf, conway.Octahedron()))
+	assert.True(t, strings.HasPrefix(buf.String(), "<svg "))
+
+	assert.ErrorIs(t, conway.WriteSVG(failingWriter{}, conway.Octahedron()), errWriteFailed)
+}
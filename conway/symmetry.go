@@ -0,0 +1,396 @@
+package conway
+
+import "slices"
+
+// noDart marks a missing dart, such as the successor of a dart on an open boundary.
+const noDart = -1
+
+// dartGraph is the rotation system of a polyhedron: every edge as two opposite
+// darts (directed edges), each linked to the next and previous dart of the face on
+// its left. Combinatorial symmetries are maps between darts that preserve these links.
+type dartGraph struct {
+	tail, head []int // Vertex indices of each dart's endpoints
+	face       []int // Face index on the dart's left, or -1 on a boundary
+	degree     []int // Degree of the face on the dart's left, or 0 on a boundary
+	next, prev []int // Neighbouring darts in the left face, or noDart
+	twin       []int // Opposite dart
+
+	vertexCount, faceCount int
+	vertexDegree           []int
+}
+
+// newDartGraph builds the rotation system from the face boundaries, in ID order.
+// If two faces traverse an edge in the same direction, the later face wins; the
+// symmetries found for such inconsistently wound meshes may be incomplete.
+func newDartGraph(p *Polyhedron) *dartGraph {
+	vertices := sortedVertices(p)
+	faces := sortedFaces(p)
+	vertexIndex := indexVertices(vertices)
+
+	g := &dartGraph{
+		tail: nil, head: nil, face: nil, degree: nil, next: nil, prev: nil, twin: nil,
+		vertexCount:  len(vertices),
+		faceCount:    len(faces),
+		vertexDegree: make([]int, len(vertices)),
+	}
+
+	darts := make(map[[2]int]int)
+
+	dartOf := func(u, v int) int {
+		if d, ok := darts[[2]int{u, v}]; ok {
+			return d
+		}
+
+		d := len(g.tail)
+		darts[[2]int{u, v}] = d
+
+		g.tail = append(g.tail, u)
+		g.head = append(g.head, v)
+		g.face = append(g.face, -1)
+		g.degree = append(g.degree, 0)
+		g.next = append(g.next, noDart)
+		g.prev = append(g.prev, noDart)
+		g.twin = append(g.twin, noDart)
+
+		return d
+	}
+
+	for fi, f := range faces {
+		n := len(f.Vertices)
+		boundary := make([]int, n)
+
+		for i := range n {
+			boundary[i] = dartOf(vertexIndex[f.Vertices[i].ID], vertexIndex[f.Vertices[(i+1)%n].ID])
+		}
+
+		for i, d := range boundary {
+			g.face[d] = fi
+			g.degree[d] = n
+			g.next[d] = boundary[(i+1)%n]
+			g.prev[d] = boundary[(i-1+n)%n]
+		}
+	}
+
+	for _, e := range sortedEdges(p) {
+		u, v := vertexIndex[e.V1.ID], vertexIndex[e.V2.ID]
+		dartOf(u, v)
+		dartOf(v, u)
+	}
+
+	for d := range g.tail {
+		g.twin[d] = dartOf(g.head[d], g.tail[d])
+		g.vertexDegree[g.tail[d]]++
+	}
+
+	return g
+}
+
+// faceDegree returns the degree of the face on a dart's left, or 0 on a boundary.
+func (g *dartGraph) faceDegree(d int) int {
+	return g.degree[d]
+}
+
+// step returns the image of a neighbour of a dart whose image is known. Mirror
+// images swap the faces on either side of each dart.
+func (g *dartGraph) step(image int, forward, mirror bool) int {
+	if !mirror {
+		if forward {
+			return g.next[image]
+		}
+
+		return g.prev[image]
+	}
+
+	var around int
+
+	if forward {
+		around = g.prev[g.twin[image]]
+	} else {
+		around = g.next[g.twin[image]]
+	}
+
+	if around == noDart {
+		return noDart
+	}
+
+	return g.twin[around]
+}
+
+// automorphism extends the map from dart start to dart target to the whole graph,
+// returning the image of every dart, or nil if no symmetry maps start to target.
+func (g *dartGraph) automorphism(start, target int, mirror bool) []int {
+	image := make([]int, len(g.tail))
+	used := make([]bool, len(g.tail))
+	vertexImage := make([]int, g.vertexCount)
+
+	for i := range image {
+		image[i] = noDart
+	}
+
+	for i := range vertexImage {
+		vertexImage[i] = -1
+	}
+
+	assign := func(d, e int) bool {
+		if d == noDart || e == noDart {
+			return d == e
+		}
+
+		if image[d] != noDart {
+			return image[d] == e
+		}
+
+		if used[e] || (vertexImage[g.tail[d]] >= 0 && vertexImage[g.tail[d]] != g.tail[e]) {
+			return false
+		}
+
+		image[d] = e
+		used[e] = true
+		vertexImage[g.tail[d]] = g.tail[e]
+
+		return true
+	}
+
+	if !assign(start, target) {
+		return nil
+	}
+
+	queue := []int{start}
+
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		e := image[d]
+
+		neighbours := [3][2]int{
+			{g.twin[d], g.twin[e]},
+			{g.next[d], g.step(e, true, mirror)},
+			{g.prev[d], g.step(e, false, mirror)},
+		}
+
+		for _, pair := range neighbours {
+			mapped := pair[0] != noDart && image[pair[0]] != noDart
+
+			if !assign(pair[0], pair[1]) {
+				return nil
+			}
+
+			if pair[0] != noDart && !mapped {
+				queue = append(queue, pair[0])
+			}
+		}
+	}
+
+	for _, e := range image {
+		if e == noDart {
+			return nil // Disconnected; the start dart's component does not determine the rest
+		}
+	}
+
+	return image
+}
+
+// automorphisms returns every combinatorial symmetry of the polyhedron as a dart
+// map, including mirror symmetries. Each symmetry is determined by the image of a
+// single dart, so the search tries every dart that colour refinement cannot
+// distinguish from a fixed start dart, chosen to have as few such darts as possible.
+func (g *dartGraph) automorphisms() []dartMap {
+	if len(g.tail) == 0 {
+		return nil
+	}
+
+	labels := g.refineVertexLabels()
+
+	dartLabel := func(d int) [3]int {
+		return [3]int{labels[g.tail[d]], labels[g.head[d]], g.faceDegree(d)}
+	}
+
+	// Candidate images of a dart must match its endpoint labels and, depending on
+	// mirroring, the degree of its left or right face.
+	candidates := make(map[[2]int][]int)
+
+	for d := range g.tail {
+		key := [2]int{labels[g.tail[d]], labels[g.head[d]]}
+		candidates[key] = append(candidates[key], d)
+	}
+
+	start := 0
+
+	for d := range g.tail {
+		if len(candidates[[2]int{labels[g.tail[d]], labels[g.head[d]]}]) <
+			len(candidates[[2]int{labels[g.tail[start]], labels[g.head[start]]}]) {
+			start = d
+		}
+	}
+
+	startLabel := dartLabel(start)
+
+	var result []dartMap
+
+	for _, target := range candidates[[2]int{startLabel[0], startLabel[1]}] {
+		for _, mirror := range []bool{false, true} {
+			// A mirror symmetry takes the face on the left of start to the face on the right of target.
+			imageFace := target
+			if mirror {
+				imageFace = g.twin[target]
+			}
+
+			if g.faceDegree(imageFace) != startLabel[2] {
+				continue
+			}
+
+			if image := g.automorphism(start, target, mirror); image != nil {
+				result = append(result, dartMap{image: image, mirror: mirror})
+			}
+		}
+	}
+
+	return result
+}
+
+// refineVertexLabels labels vertices by colour refinement: starting from each
+// vertex's degree and the degrees of its faces, labels are repeatedly split by the
+// multiset of neighbouring labels until they stabilize. Vertices in the same orbit
+// always share a label, so labels prune the candidates for symmetries.
+func (g *dartGraph) refineVertexLabels() []int {
+	labels := make([]int, g.vertexCount)
+	signatures := make([][]int, g.vertexCount)
+
+	for d := range g.tail {
+		signatures[g.tail[d]] = append(signatures[g.tail[d]], g.faceDegree(d))
+	}
+
+	for v := range signatures {
+		slices.Sort(signatures[v])
+		signatures[v] = append(signatures[v], g.vertexDegree[v])
+	}
+
+	count := compressLabels(signatures, labels)
+
+	for {
+		for v := range signatures {
+			signatures[v] = append(signatures[v][:0], labels[v])
+		}
+
+		for d := range g.tail {
+			signatures[g.tail[d]] = append(signatures[g.tail[d]], labels[g.head[d]])
+		}
+
+		for v := range signatures {
+			slices.Sort(signatures[v][1:])
+		}
+
+		refined := compressLabels(signatures, labels)
+		if refined == count {
+			return labels
+		}
+
+		count = refined
+	}
+}
+
+// compressLabels numbers distinct signatures in sorted order, writing each index's
+// number to labels, and returns the number of distinct signatures.
+func compressLabels(signatures [][]int, labels []int) int {
+	distinct := slices.Clone(signatures)
+	slices.SortFunc(distinct, slices.Compare)
+	distinct = slices.CompactFunc(distinct, slices.Equal)
+
+	for i, sig := range signatures {
+		labels[i], _ = slices.BinarySearchFunc(distinct, sig, slices.Compare)
+	}
+
+	return len(distinct)
+}
+
+// dartMap is a symmetry as the image of every dart. Mirror symmetries exchange the
+// faces on either side of each dart.
+type dartMap struct {
+	image  []int
+	mirror bool
+}
+
+// faceImage returns the index of the face that the face on the left of dart d maps to.
+func (g *dartGraph) faceImage(m dartMap, d int) int {
+	if m.mirror {
+		return g.face[g.twin[m.image[d]]]
+	}
+
+	return g.face[m.image[d]]
+}
+
+// symmetryOrbits partitions the faces and vertices of a polyhedron into orbits under
+// its combinatorial symmetries. Orbits are returned as class numbers per face and
+// vertex index, in ID order; for the canonical forms generated by the operations
+// these match the geometric symmetry orbits.
+func symmetryOrbits(p *Polyhedron) ([]int, []int) {
+	g := newDartGraph(p)
+
+	faces := newUnionFind(g.faceCount)
+	vertices := newUnionFind(g.vertexCount)
+
+	for _, m := range g.automorphisms() {
+		for d, e := range m.image {
+			vertices.union(g.tail[d], g.tail[e])
+
+			if g.face[d] >= 0 && g.faceImage(m, d) >= 0 {
+				faces.union(g.face[d], g.faceImage(m, d))
+			}
+		}
+	}
+
+	return faces.classes(), vertices.classes()
+}
+
+// unionFind is a disjoint-set forest over indices.
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+
+	return i
+}
+
+func (u *unionFind) union(a, b int) {
+	ra, rb := u.find(a), u.find(b)
+
+	// Keep the lowest index as the root so class numbering is deterministic.
+	if ra < rb {
+		u.parent[rb] = ra
+	} else if rb < ra {
+		u.parent[ra] = rb
+	}
+}
+
+// classes numbers the sets from 0 in order of their lowest index.
+func (u *unionFind) classes() []int {
+	classes := make([]int, len(u.parent))
+	numbers := make(map[int]int)
+
+	for i := range u.parent {
+		root := u.find(i)
+
+		if _, ok := numbers[root]; !ok {
+			numbers[root] = len(numbers)
+		}
+
+		classes[i] = numbers[root]
+	}
+
+	return classes
+}
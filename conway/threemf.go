@@ -0,0 +1,319 @@
+package conway
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	// defaultCircumradius is the default distance from the centre to the furthest
+	// vertex in 3MF output, in the output unit: a 50 mm wide print by default.
+	defaultCircumradius = 25
+	// threeMFPrecision is the number of decimals written for 3MF coordinates.
+	threeMFPrecision = 6
+
+	threeMFModelPath     = "3D/3dmodel.model"
+	threeMFCoreNamespace = "http://schemas.microsoft.com/3dmanufacturing/core/2015/02"
+	threeMFMatNamespace  = "http://schemas.microsoft.com/3dmanufacturing/material/2015/02"
+	threeMFContentTypes  = `<?xml version="1.0" encoding="UTF-8"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+  <Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+  <Default Extension="model" ContentType="application/vnd.ms-package.3dmanufacturing-3dmodel+xml"/>
+</Types>
+`
+	threeMFRelationships = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Target="/` + threeMFModelPath + `" Id="rel0" Type="http://schemas.microsoft.com/3dmanufacturing/2013/01/3dmodel"/>
+</Relationships>
+`
+)
+
+// Unit is the length unit of a 3MF model.
+type Unit int
+
+const (
+	// Millimeter is the default 3MF unit.
+	Millimeter Unit = iota
+	// Micron is a thousandth of a millimetre.
+	Micron
+	// Centimeter is ten millimetres.
+	Centimeter
+	// Meter is a thousand millimetres.
+	Meter
+	// Inch is 25.4 millimetres.
+	Inch
+	// Foot is twelve inches.
+	Foot
+)
+
+// String returns the unit's name as written in 3MF files.
+func (u Unit) String() string {
+	switch u {
+	case Millimeter:
+		return "millimeter"
+	case Micron:
+		return "micron"
+	case Centimeter:
+		return "centimeter"
+	case Meter:
+		return "meter"
+	case Inch:
+		return "inch"
+	case Foot:
+		return "foot"
+	default:
+		return fmt.Sprintf("Unit(%d)", int(u))
+	}
+}
+
+// scaleMode selects which measurement of the polyhedron a 3MF scale option fixes.
+type scaleMode int
+
+const (
+	scaleByCircumradius scaleMode = iota
+	scaleByEdgeLength
+	scaleByFactor
+)
+
+// ThreeMFOption configures 3MF export.
+type ThreeMFOption func(*threeMFConfig)
+
+// threeMFConfig holds 3MF export settings.
+type threeMFConfig struct {
+	unit  Unit
+	mode  scaleMode
+	value float64
+}
+
+func newThreeMFConfig(opts []ThreeMFOption) threeMFConfig {
+	cfg := threeMFConfig{unit: Millimeter, mode: scaleByCircumradius, value: defaultCircumradius}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithUnit sets the model's length unit. The default is Millimeter.
+func WithUnit(unit Unit) ThreeMFOption {
+	return func(cfg *threeMFConfig) {
+		cfg.unit = unit
+	}
+}
+
+// WithCircumradius scales the model so its furthest vertex is radius units from its
+// centroid. This is the default, with a radius of 25.
+func WithCircumradius(radius float64) ThreeMFOption {
+	return func(cfg *threeMFConfig) {
+		cfg.mode, cfg.value = scaleByCircumradius, radius
+	}
+}
+
+// WithEdgeLength scales the model so its mean edge length is length units, which for
+// uniform polyhedra is every edge's length.
+func WithEdgeLength(length float64) ThreeMFOption {
+	return func(cfg *threeMFConfig) {
+		cfg.mode, cfg.value = scaleByEdgeLength, length
+	}
+}
+
+// WithScale multiplies the polyhedron's own coordinates by factor to give lengths in
+// the model's unit.
+func WithScale(factor float64) ThreeMFOption {
+	return func(cfg *threeMFConfig) {
+		cfg.mode, cfg.value = scaleByFactor, factor
+	}
+}
+
+// scaleFactor returns the factor from the polyhedron's coordinates to model units.
+func (cfg threeMFConfig) scaleFactor(p *Polyhedron, center Vector3) float64 {
+	var measure float64
+
+	switch cfg.mode {
+	case scaleByCircumradius:
+		for _, v := range p.Vertices {
+			measure = math.Max(measure, v.Position.Distance(center))
+		}
+	case scaleByEdgeLength:
+		for _, e := range p.Edges {
+			measure += e.Length() / float64(len(p.Edges))
+		}
+	case scaleByFactor:
+		return cfg.value
+	}
+
+	if measure == 0 {
+		return 1
+	}
+
+	return cfg.value / measure
+}
+
+// Write3MF writes the polyhedron as a 3MF package for 3D printing: a zip archive
+// holding a model with a single mesh object named after the polyhedron. Faces are
+// triangulated by ear clipping in their own winding order, and share vertices so the
+// mesh is watertight. The model is scaled as set by the options, centred on the
+// origin in X and Y and resting on the build plate at Z = 0.
+//
+// Faces with a ColorAttribute are coloured through a colour group; faces without one
+// take the default style's fill colour.
+func Write3MF(w io.Writer, p *Polyhedron, opts ...ThreeMFOption) error {
+	cfg := newThreeMFConfig(opts)
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"[Content_Types].xml", func(w io.Writer) error { _, err := io.WriteString(w, threeMFContentTypes); return err }},
+		{"_rels/.rels", func(w io.Writer) error { _, err := io.WriteString(w, threeMFRelationships); return err }},
+		{threeMFModelPath, func(w io.Writer) error { return writeThreeMFModel(w, p, cfg) }},
+	}
+
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("writing 3MF: %w", err)
+		}
+
+		if err := part.write(fw); err != nil {
+			return fmt.Errorf("writing 3MF: %w", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("writing 3MF: %w", err)
+	}
+
+	return nil
+}
+
+// writeThreeMFModel writes the 3D model part.
+func writeThreeMFModel(w io.Writer, p *Polyhedron, cfg threeMFConfig) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	center := p.calculateCentroidUnsafe()
+	scale := cfg.scaleFactor(p, center)
+	vertices := sortedVertices(p)
+	faces := sortedFaces(p)
+	index := indexVertices(vertices)
+
+	// Translate so the centroid is over the origin and the lowest vertex is on the plate.
+	lowest := math.Inf(1)
+
+	for _, v := range vertices {
+		lowest = math.Min(lowest, v.Position.Z)
+	}
+
+	offset := Vector3{X: -center.X, Y: -center.Y, Z: -lowest}
+
+	colors, faceColor := threeMFColors(faces)
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<model unit="%s" xml:lang="en-US" xmlns="%s" xmlns:m="%s">`+"\n", cfg.unit, threeMFCoreNamespace, threeMFMatNamespace)
+
+	bw.WriteString(`  <metadata name="Title">`)
+	_ = xml.EscapeText(bw, []byte(p.Name)) // Writes to the buffered writer cannot fail until Flush
+	bw.WriteString("</metadata>\n  <resources>\n")
+
+	// The colour group is resource 1 and the object resource 2.
+	properties := ""
+
+	if len(colors) > 0 {
+		bw.WriteString(`    <m:colorgroup id="1">` + "\n")
+
+		for _, c := range colors {
+			fmt.Fprintf(bw, `      <m:color color="%s"/>`+"\n", threeMFColor(c))
+		}
+
+		bw.WriteString("    </m:colorgroup>\n")
+
+		properties = ` pid="1" pindex="0"`
+	}
+
+	fmt.Fprintf(bw, `    <object id="2" type="model" name="%s"%s>`+"\n      <mesh>\n        <vertices>\n", xmlAttr(p.Name), properties)
+
+	for _, v := range vertices {
+		pos := v.Position.Add(offset).Scale(scale)
+		fmt.Fprintf(bw, `          <vertex x="%s" y="%s" z="%s"/>`+"\n",
+			formatDecimal(pos.X, threeMFPrecision), formatDecimal(pos.Y, threeMFPrecision), formatDecimal(pos.Z, threeMFPrecision))
+	}
+
+	bw.WriteString("        </vertices>\n        <triangles>\n")
+
+	for _, f := range faces {
+		color := ""
+		if i, ok := faceColor[f.ID]; ok {
+			color = fmt.Sprintf(` pid="1" p1="%d"`, i)
+		}
+
+		for _, tri := range f.Triangulate(EarClipping) {
+			fmt.Fprintf(bw, `          <triangle v1="%d" v2="%d" v3="%d"%s/>`+"\n",
+				index[f.Vertices[tri[0]].ID], index[f.Vertices[tri[1]].ID], index[f.Vertices[tri[2]].ID], color)
+		}
+	}
+
+	bw.WriteString("        </triangles>\n      </mesh>\n    </object>\n  </resources>\n" +
+		"  <build>\n    <item objectid=\"2\"/>\n  </build>\n</model>\n")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("model: %w", err)
+	}
+
+	return nil
+}
+
+// threeMFColors returns the colour group entries and each coloured face's index into
+// them. Entry 0 is the default colour for faces without a ColorAttribute; the group
+// is empty if no face has one.
+func threeMFColors(faces []*Face) ([]Color, map[int]int) {
+	colorKey := ColorAttribute()
+	colors := []Color{DefaultStyle().Fill}
+	seen := make(map[Color]int)
+	faceColor := make(map[int]int)
+
+	for _, f := range faces {
+		c, ok := colorKey.Get(f)
+		if !ok {
+			continue
+		}
+
+		i, found := seen[c]
+		if !found {
+			i = len(colors)
+			seen[c] = i
+			colors = append(colors, c)
+		}
+
+		faceColor[f.ID] = i
+	}
+
+	if len(faceColor) == 0 {
+		return nil, faceColor
+	}
+
+	return colors, faceColor
+}
+
+// threeMFColor formats a colour as #RRGGBBAA.
+func threeMFColor(c Color) string {
+	return strings.ToUpper(c.Hex()) + fmt.Sprintf("%02X", int(math.Round(clampChannel(c.A)*colorChannelMax)))
+}
+
+// xmlAttr escapes a string for use in a double-quoted XML attribute.
+func xmlAttr(s string) string {
+	var sb strings.Builder
+
+	_ = xml.EscapeText(&sb, []byte(s)) // strings.Builder never returns an error
+
+	return sb.String()
+}
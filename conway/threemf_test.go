@@ -0,0 +1,217 @@
+package conway_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// threeMFModel is the subset of a 3MF model inspected by the tests.
+type threeMFModel struct {
+	Unit     string `xml:"unit,attr"`
+	Metadata []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"metadata"`
+	Resources struct {
+		ColorGroups []struct {
+			ID     int `xml:"id,attr"`
+			Colors []struct {
+				Color string `xml:"color,attr"`
+			} `xml:"color"`
+		} `xml:"colorgroup"`
+		Objects []struct {
+			ID       int    `xml:"id,attr"`
+			Name     string `xml:"name,attr"`
+			Vertices []struct {
+				X float64 `xml:"x,attr"`
+				Y float64 `xml:"y,attr"`
+				Z float64 `xml:"z,attr"`
+			} `xml:"mesh>vertices>vertex"`
+			Triangles []struct {
+				V1 int  `xml:"v1,attr"`
+				V2 int  `xml:"v2,attr"`
+				V3 int  `xml:"v3,attr"`
+				P1 *int `xml:"p1,attr"`
+			} `xml:"mesh>triangles>triangle"`
+		} `xml:"object"`
+	} `xml:"resources"`
+	Items []struct {
+		ObjectID int `xml:"objectid,attr"`
+	} `xml:"build>item"`
+}
+
+func readThreeMF(t *testing.T, p *conway.Polyhedron, opts ...conway.ThreeMFOption) threeMFModel {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, conway.Write3MF(&buf, p, opts...))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+
+		files[f.Name] = data
+	}
+
+	require.Contains(t, files, "[Content_Types].xml")
+	require.Contains(t, files, "_rels/.rels")
+	require.Contains(t, files, "3D/3dmodel.model")
+
+	var model threeMFModel
+
+	require.NoError(t, xml.Unmarshal(files["3D/3dmodel.model"], &model))
+	require.Len(t, model.Resources.Objects, 1, "a single mesh object")
+
+	return model
+}
+
+func TestWrite3MF(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("tC")
+	model := readThreeMF(t, p)
+	object := model.Resources.Objects[0]
+
+	assert.Equal(t, "millimeter", model.Unit)
+	assert.Equal(t, p.Name, object.Name)
+	require.Len(t, model.Items, 1)
+	assert.Equal(t, object.ID, model.Items[0].ObjectID)
+	assert.Empty(t, model.Resources.ColorGroups, "no colour group without colours")
+
+	assert.Len(t, object.Vertices, len(p.Vertices), "vertices are shared between faces")
+	assert.Len(t, object.Triangles, 8*1+6*6)
+
+	// Every directed edge appears once and its reverse once: the mesh is closed and
+	// consistently wound.
+	directed := make(map[[2]int]int)
+
+	for _, tri := range object.Triangles {
+		directed[[2]int{tri.V1, tri.V2}]++
+		directed[[2]int{tri.V2, tri.V3}]++
+		directed[[2]int{tri.V3, tri.V1}]++
+	}
+
+	for edge, count := range directed {
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 1, directed[[2]int{edge[1], edge[0]}])
+	}
+
+	// The signed volume is positive when triangles wind outwards.
+	volume := 0.0
+
+	for _, tri := range object.Triangles {
+		a, b, c := object.Vertices[tri.V1], object.Vertices[tri.V2], object.Vertices[tri.V3]
+		av := conway.Vector3{X: a.X, Y: a.Y, Z: a.Z}
+		bv := conway.Vector3{X: b.X, Y: b.Y, Z: b.Z}
+		cv := conway.Vector3{X: c.X, Y: c.Y, Z: c.Z}
+		volume += av.Dot(bv.Cross(cv)) / 6
+	}
+
+	assert.Positive(t, volume)
+}
+
+func TestWrite3MFScale(t *testing.T) {
+	t.Parallel()
+
+	p := conway.Cube()
+
+	extent := func(model threeMFModel) (float64, float64, float64) {
+		lowest, highest, radius := math.Inf(1), math.Inf(-1), 0.0
+		vertices := model.Resources.Objects[0].Vertices
+
+		for _, v := range vertices {
+			lowest = math.Min(lowest, v.Z)
+			highest = math.Max(highest, v.Z)
+		}
+
+		for _, v := range vertices {
+			radius = math.Max(radius, math.Sqrt(v.X*v.X+v.Y*v.Y+(v.Z-(lowest+highest)/2)*(v.Z-(lowest+highest)/2)))
+		}
+
+		return lowest, highest, radius
+	}
+
+	lowest, _, radius := extent(readThreeMF(t, p))
+	assert.InDelta(t, 0, lowest, 1e-6, "the model rests on the build plate")
+	assert.InDelta(t, 25, radius, 1e-5, "default circumradius is 25 units")
+
+	_, _, radius = extent(readThreeMF(t, p, conway.WithCircumradius(40), conway.WithUnit(conway.Inch)))
+	assert.InDelta(t, 40, radius, 1e-5)
+
+	lowest, highest, _ := extent(readThreeMF(t, p, conway.WithEdgeLength(10)))
+	assert.InDelta(t, 10, highest-lowest, 1e-5, "a cube's height is its edge length")
+
+	model := readThreeMF(t, p, conway.WithScale(3), conway.WithUnit(conway.Centimeter))
+	assert.Equal(t, "centimeter", model.Unit)
+
+	lowest, highest, _ = extent(model)
+	edge := p.Edges[lowestEdgeID(p)].Length()
+	assert.InDelta(t, 3*edge, highest-lowest, 1e-5)
+}
+
+// lowestEdgeID returns the smallest edge ID of the polyhedron.
+func lowestEdgeID(p *conway.Polyhedron) int {
+	lowest := math.MaxInt
+
+	for id := range p.Edges {
+		lowest = min(lowest, id)
+	}
+
+	return lowest
+}
+
+func TestWrite3MFColors(t *testing.T) {
+	t.Parallel()
+
+	p := conway.MustParse("tC")
+	octagon := conway.RGB(1, 0, 0)
+
+	for _, f := range p.Faces {
+		if len(f.Vertices) == 8 {
+			conway.ColorAttribute().Set(f, octagon)
+		}
+	}
+
+	model := readThreeMF(t, p)
+
+	require.Len(t, model.Resources.ColorGroups, 1)
+
+	group := model.Resources.ColorGroups[0]
+	require.Len(t, group.Colors, 2, "the default colour and red")
+	assert.Equal(t, "#FF0000FF", group.Colors[1].Color)
+
+	colored := 0
+
+	for _, tri := range model.Resources.Objects[0].Triangles {
+		if tri.P1 != nil {
+			assert.Equal(t, 1, *tri.P1)
+			colored++
+		}
+	}
+
+	assert.Equal(t, 6*6, colored, "every octagon triangle is red")
+}
+
+func TestWrite3MFError(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, conway.Write3MF(failingWriter{}, conway.Cube()), errWriteFailed)
+}
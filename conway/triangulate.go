@@ -0,0 +1,255 @@
+package conway
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TriangulationMethod selects how faces are split into triangles.
+type TriangulationMethod int
+
+const (
+	// EarClipping repeatedly cuts off a corner whose triangle holds no other vertex,
+	// so non-convex planar faces are covered exactly, without triangles outside the
+	// face. It is the default.
+	EarClipping TriangulationMethod = iota
+	// FanTriangulation joins the first vertex to every other, which is exact only for
+	// convex faces.
+	FanTriangulation
+	// CentroidSplit joins each edge to the face's centroid, adding a vertex but giving
+	// evenly shaped triangles on regular faces.
+	CentroidSplit
+)
+
+// String returns the method's name.
+func (m TriangulationMethod) String() string {
+	switch m {
+	case EarClipping:
+		return "ear-clipping"
+	case FanTriangulation:
+		return "fan"
+	case CentroidSplit:
+		return "centroid"
+	default:
+		return fmt.Sprintf("TriangulationMethod(%d)", int(m))
+	}
+}
+
+// Triangulate splits the face into triangles wound as the face is, returned as
+// indices into its Vertices. For CentroidSplit, index Degree() stands for the face's
+// centroid. Faces with fewer than three vertices have no triangles.
+func (f *Face) Triangulate(method TriangulationMethod) [][3]int {
+	n := len(f.Vertices)
+	if n < 3 {
+		return nil
+	}
+
+	switch method {
+	case CentroidSplit:
+		triangles := make([][3]int, n)
+
+		for i := range n {
+			triangles[i] = [3]int{i, (i + 1) % n, n}
+		}
+
+		return triangles
+	case FanTriangulation:
+		return fanIndices(n)
+	default:
+		points := make([]Vector3, n)
+
+		for i, v := range f.Vertices {
+			points[i] = v.Position
+		}
+
+		return clipEars(points)
+	}
+}
+
+// fanIndices triangulates a polygon of n corners as a fan from its first corner.
+func fanIndices(n int) [][3]int {
+	triangles := make([][3]int, 0, n-2)
+
+	for i := 1; i+1 < n; i++ {
+		triangles = append(triangles, [3]int{0, i, i + 1})
+	}
+
+	return triangles
+}
+
+// clipEars triangulates a simple polygon by ear clipping. Corners are classified as
+// convex or reflex against the polygon's Newell normal, so the polygon may be
+// slightly non-planar. If no ear can be found, as in a self-intersecting polygon, the
+// next corner is cut off anyway so every corner is still used.
+func clipEars(points []Vector3) [][3]int {
+	if len(points) == 3 {
+		return [][3]int{{0, 1, 2}}
+	}
+
+	normal := newellVector(points)
+	if normal.Length() == 0 {
+		return fanIndices(len(points))
+	}
+
+	remaining := make([]int, len(points))
+
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	triangles := make([][3]int, 0, len(points)-2)
+
+	for i, failures := 0, 0; len(remaining) > 3; {
+		m := len(remaining)
+		i %= m
+		prev, corner, next := remaining[(i+m-1)%m], remaining[i], remaining[(i+1)%m]
+
+		if failures < m && !isEar(points, remaining, prev, corner, next, normal) {
+			i++
+			failures++
+
+			continue
+		}
+
+		triangles = append(triangles, [3]int{prev, corner, next})
+		remaining = slices.Delete(remaining, i, i+1)
+		failures = 0
+	}
+
+	return append(triangles, [3]int{remaining[0], remaining[1], remaining[2]})
+}
+
+// isEar reports whether the corner turns the same way as the polygon and its
+// triangle holds none of the other remaining corners.
+func isEar(points []Vector3, remaining []int, prev, corner, next int, normal Vector3) bool {
+	a, b, c := points[prev], points[corner], points[next]
+
+	if b.Sub(a).Cross(c.Sub(b)).Dot(normal) <= 0 {
+		return false
+	}
+
+	for _, i := range remaining {
+		if i == prev || i == corner || i == next {
+			continue
+		}
+
+		p := points[i]
+		if p == a || p == b || p == c {
+			continue
+		}
+
+		if b.Sub(a).Cross(p.Sub(a)).Dot(normal) >= 0 &&
+			c.Sub(b).Cross(p.Sub(b)).Dot(normal) >= 0 &&
+			a.Sub(c).Cross(p.Sub(c)).Dot(normal) >= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TriangulateOp splits every face into triangles without moving any vertex, keeping
+// the faces' winding. CentroidSplit adds a vertex at each face's centroid, in the
+// face's plane, unlike kis. Workers sets how many goroutines build the output; zero
+// uses one per available CPU.
+type TriangulateOp struct {
+	Method  TriangulationMethod
+	Workers int
+}
+
+func (t TriangulateOp) Symbol() string {
+	return "Δ"
+}
+
+func (t TriangulateOp) Name() string {
+	return "triangulate"
+}
+
+func (t TriangulateOp) Apply(p *Polyhedron) *Polyhedron {
+	vertices := sortedVertices(p)
+	faces := sortedFaces(p)
+	vertexIndex := indexVertices(vertices)
+
+	triangles := make([][][3]int, len(faces))
+	offsets := make([]int, len(faces)+1)
+
+	for i, face := range faces {
+		triangles[i] = face.Triangulate(t.Method)
+		offsets[i+1] = offsets[i] + len(triangles[i])
+	}
+
+	centroids := 0
+	if t.Method == CentroidSplit {
+		centroids = len(faces)
+	}
+
+	b := newMeshBuilder(len(vertices)+centroids, offsets[len(faces)])
+	b.wound = true
+
+	b.trace(t.Symbol(), p, func(a, c int) Source {
+		if a > c {
+			a, c = c, a
+		}
+
+		if c >= len(vertices) {
+			return Source{Kind: FaceElement, ID: faces[c-len(vertices)].ID}
+		}
+
+		if e := edgeBetween(vertices[a], vertices[c]); e != nil {
+			return Source{Kind: EdgeElement, ID: e.ID}
+		}
+
+		return Source{Kind: FaceElement, ID: diagonalFace(vertices[a], vertices[c])}
+	})
+
+	for i, v := range vertices {
+		b.positions[i] = v.Position
+		b.vertexSources[i] = Source{Kind: VertexElement, ID: v.ID}
+	}
+
+	parallelFor(t.Workers, len(faces), func(start, end int) {
+		for i := start; i < end; i++ {
+			face := faces[i]
+			corners := make([]int, len(face.Vertices), len(face.Vertices)+1)
+
+			for j, v := range face.Vertices {
+				corners[j] = vertexIndex[v.ID]
+			}
+
+			if centroids > 0 {
+				centre := len(vertices) + i
+				b.positions[centre] = face.Centroid()
+				b.vertexSources[centre] = Source{Kind: FaceElement, ID: face.ID}
+				corners = append(corners, centre)
+			}
+
+			for j, tri := range triangles[i] {
+				b.faces[offsets[i]+j] = []int{corners[tri[0]], corners[tri[1]], corners[tri[2]]}
+				b.faceSources[offsets[i]+j] = Source{Kind: FaceElement, ID: face.ID}
+			}
+		}
+	})
+
+	return b.build(t.Symbol()+p.Name, t.Workers)
+}
+
+// diagonalFace returns the lowest ID of the faces containing both vertices, the face
+// a diagonal between them was cut across.
+func diagonalFace(v1, v2 *Vertex) int {
+	id := -1
+
+	for fid := range v1.Faces {
+		if _, ok := v2.Faces[fid]; ok && (id < 0 || fid < id) {
+			id = fid
+		}
+	}
+
+	return id
+}
+
+// Triangulate returns a copy of the polyhedron with every face split into triangles
+// by ear clipping.
+func Triangulate(p *Polyhedron) *Polyhedron {
+	op := TriangulateOp{Method: EarClipping, Workers: 0}
+	return op.Apply(p)
+}
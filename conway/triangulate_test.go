@@ -0,0 +1,118 @@
+package conway_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/sksmith/conway/conway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lPrism returns a prism on an L-shaped hexagon of area 3 with its lid, whose first
+// vertex is a corner from which a fan leaves the face.
+func lPrism() (*conway.Polyhedron, *conway.Face) {
+	p := conway.NewPolyhedron("L prism")
+	outline := [][2]float64{{2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}, {0, 0}}
+
+	var bottom, top []*conway.Vertex
+
+	for _, xy := range outline {
+		bottom = append(bottom, p.AddVertex(conway.Vector3{X: xy[0], Y: xy[1], Z: 0}))
+		top = append(top, p.AddVertex(conway.Vector3{X: xy[0], Y: xy[1], Z: 1}))
+	}
+
+	base := slices.Clone(bottom)
+	slices.Reverse(base)
+	p.AddFace(base)
+
+	lid := p.AddFace(top)
+
+	for i := range outline {
+		j := (i + 1) % len(outline)
+		p.AddFace([]*conway.Vertex{bottom[i], bottom[j], top[j], top[i]})
+	}
+
+	return p, lid
+}
+
+// triangleAreas returns the unsigned area of the triangles and the number wound
+// against the face.
+func triangleAreas(f *conway.Face, triangles [][3]int) (float64, int) {
+	area, backwards := 0.0, 0
+
+	for _, tri := range triangles {
+		a, b, c := f.Vertices[tri[0]].Position, f.Vertices[tri[1]].Position, f.Vertices[tri[2]].Position
+		cross := b.Sub(a).Cross(c.Sub(a))
+		area += cross.Length() / 2
+
+		if cross.Dot(f.Normal()) < 0 {
+			backwards++
+		}
+	}
+
+	return area, backwards
+}
+
+func TestFaceTriangulate(t *testing.T) {
+	t.Parallel()
+
+	_, lid := lPrism()
+	require.Equal(t, 2.0, lid.Vertices[0].Position.X)
+	assert.InDelta(t, 3.0, lid.Area(), 1e-12)
+
+	ears := lid.Triangulate(conway.EarClipping)
+	require.Len(t, ears, 4)
+
+	area, backwards := triangleAreas(lid, ears)
+	assert.InDelta(t, 3.0, area, 1e-12, "ears cover the face exactly")
+	assert.Zero(t, backwards)
+
+	fan := lid.Triangulate(conway.FanTriangulation)
+	require.Len(t, fan, 4)
+
+	area, backwards = triangleAreas(lid, fan)
+	assert.InDelta(t, 4.0, area, 1e-12, "the fan overlaps itself across the reflex corner")
+	assert.Equal(t, 1, backwards)
+
+	split := lid.Triangulate(conway.CentroidSplit)
+	require.Len(t, split, 6)
+
+	for i, tri := range split {
+		assert.Equal(t, [3]int{i, (i + 1) % 6, 6}, tri)
+	}
+
+	assert.Equal(t, "ear-clipping", conway.EarClipping.String())
+	assert.Equal(t, "TriangulationMethod(7)", conway.TriangulationMethod(7).String())
+}
+
+func TestTriangulate(t *testing.T) {
+	t.Parallel()
+
+	cube := conway.MustParse("C")
+
+	q := conway.Triangulate(cube)
+	assert.Equal(t, "ΔCube: V=8, E=18, F=12, χ=2", q.Stats())
+	assert.InDelta(t, cube.Volume(), q.Volume(), 1e-12)
+	require.NoError(t, q.ValidateWinding())
+	require.NotNil(t, q.Provenance)
+	assert.Equal(t, "Δ", q.Provenance.Operation)
+
+	op := conway.TriangulateOp{Method: conway.CentroidSplit, Workers: 2}
+	split := op.Apply(cube)
+	assert.Equal(t, "ΔCube: V=14, E=36, F=24, χ=2", split.Stats())
+	assert.InDelta(t, cube.Volume(), split.Volume(), 1e-12, "centroids stay in the face planes")
+	require.NoError(t, split.ValidateWinding())
+
+	p, _ := lPrism()
+	l := conway.Triangulate(p)
+	assert.Len(t, l.Faces, 2*4+2*6)
+	assert.InDelta(t, 3.0, math.Abs(l.Volume()), 1e-12)
+	require.NoError(t, l.ValidateWinding())
+	assert.Empty(t, l.SelfIntersections())
+
+	for _, f := range l.Faces {
+		assert.Equal(t, 3, f.Degree())
+	}
+}
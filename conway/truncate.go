@@ -7,7 +7,11 @@ const (
 	defaultTruncateFactor = 1.0 / 3.0
 )
 
-type TruncateOp struct{}
+// TruncateOp cuts off every vertex. Workers sets how many goroutines build
+// the output; zero uses one per available CPU.
+type TruncateOp struct {
+	Workers int
+}
 
 func (t TruncateOp) Symbol() string {
 	return "t"
@@ -17,32 +21,47 @@ func (t TruncateOp) Name() string {
 	return "truncate"
 }
 
-// createTruncatedEdgeVertices creates new vertices along each edge for truncation.
-func createTruncatedEdgeVertices(p *Polyhedron, truncFactor float64) (
-	map[string]*Vertex, *Polyhedron,
-) {
-	trunc := NewPolyhedron("t" + p.Name)
-
-	edgeVertices := make(map[string]*Vertex)
+// truncatedEdgeVertex returns the output index of the cut point on the edge
+// at edgeIdx nearest to the given endpoint. Each edge owns two consecutive slots.
+func truncatedEdgeVertex(edge *Edge, edgeIdx, vertexID int) int {
+	if edge.V1.ID == vertexID {
+		return 2 * edgeIdx
+	}
 
-	for _, edge := range p.Edges {
-		v1Pos := edge.V1.Position
+	return 2*edgeIdx + 1
+}
 
-		v2Pos := edge.V2.Position
+// createTruncatedEdgeVertices places two new vertices along each edge for truncation.
+func createTruncatedEdgeVertices(b *meshBuilder, edges []*Edge, truncFactor float64, workers int) {
+	parallelFor(workers, len(edges), func(start, end int) {
+		for i := start; i < end; i++ {
+			v1Pos := edges[i].V1.Position
 
-		newV1Pos := v1Pos.Add(v2Pos.Sub(v1Pos).Scale(truncFactor))
+			v2Pos := edges[i].V2.Position
 
-		newV2Pos := v1Pos.Add(v2Pos.Sub(v1Pos).Scale(1 - truncFactor))
+			b.positions[2*i] = v1Pos.Add(v2Pos.Sub(v1Pos).Scale(truncFactor))
+			b.positions[2*i+1] = v1Pos.Add(v2Pos.Sub(v1Pos).Scale(1 - truncFactor))
+			b.vertexSources[2*i] = Source{Kind: EdgeElement, ID: edges[i].ID}
+			b.vertexSources[2*i+1] = Source{Kind: EdgeElement, ID: edges[i].ID}
+		}
+	})
+}
 
-		key1 := EdgeVertexKey(edge.ID, edge.V1.ID)
+// truncatedEdgeSource returns the source of the edge between two cut points.
+// Cut points on the same original edge came from that edge; otherwise they
+// border a vertex face and came from the original vertex they surround.
+func truncatedEdgeSource(edges []*Edge, a, b int) Source {
+	if a/2 == b/2 {
+		return Source{Kind: EdgeElement, ID: edges[a/2].ID}
+	}
 
-		key2 := EdgeVertexKey(edge.ID, edge.V2.ID)
+	corner := edges[a/2].V1
 
-		edgeVertices[key1] = trunc.AddVertex(newV1Pos)
-		edgeVertices[key2] = trunc.AddVertex(newV2Pos)
+	if a%2 == 1 {
+		corner = edges[a/2].V2
 	}
 
-	return edgeVertices, trunc
+	return Source{Kind: VertexElement, ID: corner.ID}
 }
 
 // findAdjacentEdges finds the edges connecting a vertex to its previous and next neighbors in a face.
@@ -64,9 +83,9 @@ func findAdjacentEdges(vertex, prevVertex, nextVertex *Vertex) (*Edge, *Edge) {
 	return edge1, edge2
 }
 
-// addTruncatedFaceVertices adds vertices for a truncated face.
-func addTruncatedFaceVertices(face *Face, edgeVertices map[string]*Vertex) []*Vertex {
-	newFaceVertices := allocateVertexSlice(len(face.Vertices) * 2)
+// addTruncatedFaceVertices returns the output vertex indices for a truncated face.
+func addTruncatedFaceVertices(face *Face, edgeIndex map[int]int) []int {
+	newFaceVertices := make([]int, 0, len(face.Vertices)*2)
 
 	for i, vertex := range face.Vertices {
 		prevVertex := face.Vertices[(i-1+len(face.Vertices))%len(face.Vertices)]
@@ -76,16 +95,12 @@ func addTruncatedFaceVertices(face *Face, edgeVertices map[string]*Vertex) []*Ve
 		edge1, edge2 := findAdjacentEdges(vertex, prevVertex, nextVertex)
 
 		if edge1 != nil && edge2 != nil {
-			key1 := EdgeVertexKey(edge1.ID, vertex.ID)
-
-			key2 := EdgeVertexKey(edge2.ID, vertex.ID)
-
-			if v1, ok := edgeVertices[key1]; ok {
-				newFaceVertices = append(newFaceVertices, v1)
+			if idx, ok := edgeIndex[edge1.ID]; ok {
+				newFaceVertices = append(newFaceVertices, truncatedEdgeVertex(edge1, idx, vertex.ID))
 			}
 
-			if v2, ok := edgeVertices[key2]; ok {
-				newFaceVertices = append(newFaceVertices, v2)
+			if idx, ok := edgeIndex[edge2.ID]; ok {
+				newFaceVertices = append(newFaceVertices, truncatedEdgeVertex(edge2, idx, vertex.ID))
 			}
 		}
 	}
@@ -93,42 +108,60 @@ func addTruncatedFaceVertices(face *Face, edgeVertices map[string]*Vertex) []*Ve
 	return newFaceVertices
 }
 
-// processTruncatedFaces processes all faces to create truncated versions.
-func processTruncatedFaces(p, trunc *Polyhedron, edgeVertices map[string]*Vertex) {
-	for _, face := range p.Faces {
-		newFaceVertices := addTruncatedFaceVertices(face, edgeVertices)
-
-		if len(newFaceVertices) >= 3 {
-			trunc.AddFace(newFaceVertices)
+// processTruncatedFaces fills the builder slots for the truncated versions of all faces.
+func processTruncatedFaces(b *meshBuilder, faces []*Face, edgeIndex map[int]int, workers int) {
+	parallelFor(workers, len(faces), func(start, end int) {
+		for i := start; i < end; i++ {
+			if newFaceVertices := addTruncatedFaceVertices(faces[i], edgeIndex); len(newFaceVertices) >= 3 {
+				b.faces[i] = newFaceVertices
+				b.faceSources[i] = Source{Kind: FaceElement, ID: faces[i].ID}
+			}
 		}
-	}
+	})
 }
 
-// processTruncatedVertexFaces processes vertices to create new faces at truncation sites.
-func processTruncatedVertexFaces(p, trunc *Polyhedron, edgeVertices map[string]*Vertex) {
-	for _, vertex := range p.Vertices {
-		vertexFaceVertices := allocateVertexSlice(vertex.Degree())
-
-		orderedEdges := OrderEdgesAroundVertex(vertex)
+// processTruncatedVertexFaces fills the builder slots for the new faces at truncation sites.
+// Slots follow the original faces, one per original vertex.
+func processTruncatedVertexFaces(
+	b *meshBuilder, vertices []*Vertex, faceCount int, edgeIndex map[int]int, workers int,
+) {
+	parallelFor(workers, len(vertices), func(start, end int) {
+		for i := start; i < end; i++ {
+			vertex := vertices[i]
 
-		for _, edge := range orderedEdges {
-			key := EdgeVertexKey(edge.ID, vertex.ID)
+			vertexFaceVertices := make([]int, 0, vertex.Degree())
 
-			if v, ok := edgeVertices[key]; ok {
-				vertexFaceVertices = append(vertexFaceVertices, v)
+			for _, edge := range OrderEdgesAroundVertex(vertex) {
+				if idx, ok := edgeIndex[edge.ID]; ok {
+					vertexFaceVertices = append(vertexFaceVertices, truncatedEdgeVertex(edge, idx, vertex.ID))
+				}
 			}
-		}
 
-		if len(vertexFaceVertices) >= 3 {
-			trunc.AddFace(vertexFaceVertices)
+			if len(vertexFaceVertices) >= 3 {
+				b.faces[faceCount+i] = vertexFaceVertices
+				b.faceSources[faceCount+i] = Source{Kind: VertexElement, ID: vertex.ID}
+			}
 		}
-	}
+	})
 }
 
 func (t TruncateOp) Apply(p *Polyhedron) *Polyhedron {
-	edgeVertices, trunc := createTruncatedEdgeVertices(p, defaultTruncateFactor)
-	processTruncatedFaces(p, trunc, edgeVertices)
-	processTruncatedVertexFaces(p, trunc, edgeVertices)
+	vertices := sortedVertices(p)
+	edges := sortedEdges(p)
+	faces := sortedFaces(p)
+	edgeIndex := indexEdges(edges)
+
+	b := newMeshBuilder(2*len(edges), len(faces)+len(vertices))
+	b.trace(t.Symbol(), p, func(a, c int) Source {
+		return truncatedEdgeSource(edges, a, c)
+	})
+
+	createTruncatedEdgeVertices(b, edges, defaultTruncateFactor, t.Workers)
+	processTruncatedFaces(b, faces, edgeIndex, t.Workers)
+	processTruncatedVertexFaces(b, vertices, len(faces), edgeIndex, t.Workers)
+
+	trunc := b.build("t"+p.Name, t.Workers)
+
 	trunc.Normalize()
 
 	return trunc
@@ -19,13 +19,28 @@ var (
 
 // EdgeLookup provides O(1) edge lookup by vertex pair.
 type EdgeLookup struct {
-	edgeMap map[string]*Edge
+	edgeMap map[edgeKey]*Edge
+}
+
+// edgeKey is the order-independent map key for a vertex pair. It avoids the
+// string formatting of MakeEdgeKey on the hot path of polyhedron construction.
+type edgeKey struct {
+	lo, hi int
+}
+
+// makeEdgeKey creates a consistent key for vertex pairs.
+func makeEdgeKey(v1ID, v2ID int) edgeKey {
+	if v1ID > v2ID {
+		v1ID, v2ID = v2ID, v1ID // Ensure consistent ordering
+	}
+
+	return edgeKey{lo: v1ID, hi: v2ID}
 }
 
 // NewEdgeLookup creates a new edge lookup structure.
 func NewEdgeLookup() *EdgeLookup {
 	return &EdgeLookup{
-		edgeMap: make(map[string]*Edge),
+		edgeMap: make(map[edgeKey]*Edge),
 	}
 }
 
@@ -40,20 +55,20 @@ func MakeEdgeKey(v1ID, v2ID int) string {
 
 // Add adds an edge to the lookup.
 func (el *EdgeLookup) Add(edge *Edge) {
-	key := MakeEdgeKey(edge.V1.ID, edge.V2.ID)
+	key := makeEdgeKey(edge.V1.ID, edge.V2.ID)
 
 	el.edgeMap[key] = edge
 }
 
 // Find finds an edge between two vertices.
 func (el *EdgeLookup) Find(v1ID, v2ID int) *Edge {
-	key := MakeEdgeKey(v1ID, v2ID)
+	key := makeEdgeKey(v1ID, v2ID)
 	return el.edgeMap[key]
 }
 
 // Remove removes an edge from the lookup.
 func (el *EdgeLookup) Remove(edge *Edge) {
-	key := MakeEdgeKey(edge.V1.ID, edge.V2.ID)
+	key := makeEdgeKey(edge.V1.ID, edge.V2.ID)
 
 	delete(el.edgeMap, key)
 }
@@ -88,6 +103,22 @@ func CalculateFaceNormal(vertices []*Vertex) (Vector3, error) {
 	return normal.Scale(1.0 / length), nil
 }
 
+// newellVector returns the normal of a polygon by Newell's method, unnormalized: its
+// length is twice the polygon's area, even for non-convex polygons.
+func newellVector(points []Vector3) Vector3 {
+	normal := Vector3{X: 0, Y: 0, Z: 0}
+
+	for i, v1 := range points {
+		v2 := points[(i+1)%len(points)]
+
+		normal.X += (v1.Y - v2.Y) * (v1.Z + v2.Z)
+		normal.Y += (v1.Z - v2.Z) * (v1.X + v2.X)
+		normal.Z += (v1.X - v2.X) * (v1.Y + v2.Y)
+	}
+
+	return normal
+}
+
 // EnsureCounterClockwise ensures face vertices are in counter-clockwise order
 // when viewed from outside the polyhedron.
 func EnsureCounterClockwise(vertices []*Vertex, polyhedronCenter Vector3) []*Vertex {
@@ -1,13 +1,37 @@
 package conway
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"slices"
+	"strings"
 )
 
 const (
 	// minFaceVertices is the minimum number of vertices required for a valid face.
 	minFaceVertices = 4
+	// minDegree is the minimum degree of a vertex or face.
+	minDegree = 3
+	// windingDotTolerance is how far below zero the dot product of a face normal and
+	// the direction from the centroid may fall before a face of an open surface is
+	// taken to be wound inwards.
+	windingDotTolerance = 0.1
+)
+
+// Names of the validation checks, used as the Type of a ValidationError and the
+// Check of a ValidationIssue.
+const (
+	checkTopology         = "Topology"
+	checkManifold         = "Manifold"
+	checkPlanarity        = "Planarity"
+	checkWinding          = "Winding"
+	checkOrientability    = "Orientability"
+	checkGeometry         = "Geometry"
+	checkConvexity        = "Convexity"
+	checkSelfIntersection = "SelfIntersection"
 )
 
 // ValidationError represents an error in polyhedron validation.
@@ -20,6 +44,224 @@ func (ve ValidationError) Error() string {
 	return fmt.Sprintf("%s validation error: %s", ve.Type, ve.Message)
 }
 
+// Severity ranks a validation issue.
+type Severity string
+
+const (
+	// SeverityError marks an issue that makes the polyhedron invalid.
+	SeverityError Severity = "error"
+	// SeverityWarning marks an issue worth attention in a valid polyhedron, such as an
+	// open boundary or a reflex edge.
+	SeverityWarning Severity = "warning"
+)
+
+// PolyhedronElement is the Element of a validation issue that concerns the polyhedron
+// as a whole rather than particular elements.
+const PolyhedronElement = "polyhedron"
+
+// ValidationIssue is one problem found by validation.
+type ValidationIssue struct {
+	Check     string   `json:"check"`         // Check that found the issue, such as "Planarity"
+	Severity  Severity `json:"severity"`      // Whether the issue makes the polyhedron invalid
+	Element   string   `json:"element"`       // ElementKind.String of the elements involved, or PolyhedronElement
+	IDs       []int    `json:"ids,omitempty"` // IDs of the elements involved
+	Message   string   `json:"message"`       // Description for people
+	Value     float64  `json:"value"`         // Measured value, such as a distance, area or count
+	Tolerance float64  `json:"tolerance"`     // Limit the value was compared with
+}
+
+// ValidationTolerances holds the limits used by validation.
+type ValidationTolerances struct {
+	// Planarity is the largest distance of a face vertex from the face's plane.
+	Planarity float64 `json:"planarity"`
+	// EdgeLength is the shortest length of an edge that is not degenerate.
+	EdgeLength float64 `json:"edgeLength"`
+	// FaceArea is the smallest area of a face that is not degenerate.
+	FaceArea float64 `json:"faceArea"`
+	// Convexity is the angle in radians by which a dihedral angle may exceed π, and the
+	// distance relative to the circumradius by which a vertex may lie in front of a
+	// face plane, in a convex polyhedron.
+	Convexity float64 `json:"convexity"`
+	// Intersection is the distance, relative to the size of the polyhedron, within
+	// which faces are taken to touch rather than cross.
+	Intersection float64 `json:"intersection"`
+}
+
+// DefaultValidationTolerances returns the tolerances used by the Validate methods.
+func DefaultValidationTolerances() ValidationTolerances {
+	return ValidationTolerances{
+		Planarity:    1e-10,
+		EdgeLength:   1e-12,
+		FaceArea:     1e-12,
+		Convexity:    1e-9,
+		Intersection: 1e-9,
+	}
+}
+
+// ValidationOption configures Validate.
+type ValidationOption func(*validationConfig)
+
+type validationConfig struct {
+	tolerances       ValidationTolerances
+	convexity        bool
+	selfIntersection bool
+}
+
+// WithTolerances replaces all validation tolerances.
+func WithTolerances(tolerances ValidationTolerances) ValidationOption {
+	return func(c *validationConfig) {
+		c.tolerances = tolerances
+	}
+}
+
+// WithPlanarityTolerance sets the largest distance of a face vertex from the face's
+// plane. The default is 1e-10.
+func WithPlanarityTolerance(tolerance float64) ValidationOption {
+	return func(c *validationConfig) {
+		c.tolerances.Planarity = tolerance
+	}
+}
+
+// WithDegenerateTolerance sets the shortest edge length and smallest face area that
+// are not degenerate. The default is 1e-12.
+func WithDegenerateTolerance(tolerance float64) ValidationOption {
+	return func(c *validationConfig) {
+		c.tolerances.EdgeLength = tolerance
+		c.tolerances.FaceArea = tolerance
+	}
+}
+
+// WithConvexityCheck adds the checks of ValidateConvexity to the report, as warnings
+// since non-convex polyhedra are valid.
+func WithConvexityCheck() ValidationOption {
+	return func(c *validationConfig) {
+		c.convexity = true
+	}
+}
+
+// WithSelfIntersectionCheck adds the check of ValidateSelfIntersection to the report.
+func WithSelfIntersectionCheck() ValidationOption {
+	return func(c *validationConfig) {
+		c.selfIntersection = true
+	}
+}
+
+// ValidationReport holds every issue found by Validate, in the order of the checks
+// run and then by element ID.
+type ValidationReport struct {
+	Name       string               `json:"name"`
+	Tolerances ValidationTolerances `json:"tolerances"`
+	Checks     []string             `json:"checks"` // Checks run, in order
+	Issues     []ValidationIssue    `json:"issues"`
+}
+
+// Valid reports whether the report has no issues of error severity.
+func (r *ValidationReport) Valid() bool {
+	return firstError(r.Issues) == nil
+}
+
+// Err returns the first issue of error severity as a ValidationError, or nil if there
+// is none.
+func (r *ValidationReport) Err() error {
+	return firstError(r.Issues)
+}
+
+// Count returns the number of issues of the given severity.
+func (r *ValidationReport) Count(severity Severity) int {
+	count := 0
+
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+
+	return count
+}
+
+// WriteJSON writes the report as indented JSON for tooling.
+func (r *ValidationReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("encoding validation report: %w", err)
+	}
+
+	return nil
+}
+
+// Validate runs the checks of ValidateComplete, and any added by options, and
+// returns every issue found rather than only the first.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) Validate(opts ...ValidationOption) *ValidationReport {
+	cfg := validationConfig{tolerances: DefaultValidationTolerances(), convexity: false, selfIntersection: false}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	report := &ValidationReport{
+		Name:       p.Name,
+		Tolerances: cfg.tolerances,
+		Checks:     []string{checkTopology, checkManifold, checkPlanarity, checkWinding, checkOrientability, checkGeometry},
+		Issues:     []ValidationIssue{},
+	}
+
+	report.Issues = append(report.Issues, p.topologyIssues()...)
+	report.Issues = append(report.Issues, p.manifoldIssues()...)
+	report.Issues = append(report.Issues, p.planarityIssues(cfg.tolerances.Planarity)...)
+	report.Issues = append(report.Issues, p.windingIssues()...)
+	report.Issues = append(report.Issues, p.orientabilityIssues()...)
+	report.Issues = append(report.Issues, p.geometryIssues(cfg.tolerances)...)
+
+	if cfg.convexity {
+		report.Checks = append(report.Checks, checkConvexity)
+
+		for _, issue := range p.convexityIssues(cfg.tolerances.Convexity) {
+			issue.Severity = SeverityWarning
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	if cfg.selfIntersection {
+		report.Checks = append(report.Checks, checkSelfIntersection)
+		report.Issues = append(report.Issues, p.selfIntersectionIssues(cfg.tolerances.Intersection)...)
+	}
+
+	return report
+}
+
+// firstError returns the first issue of error severity as a ValidationError, or nil.
+func firstError(issues []ValidationIssue) error {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return ValidationError{Type: issue.Check, Message: issue.Message}
+		}
+	}
+
+	return nil
+}
+
+// joinIssues returns a ValidationError listing every issue after a summary, or nil if
+// there are none.
+func joinIssues(check, summary string, issues []ValidationIssue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(issues))
+
+	for i, issue := range issues {
+		messages[i] = issue.Message
+	}
+
+	return ValidationError{Type: check, Message: summary + ": " + strings.Join(messages, "; ")}
+}
+
 // ValidateManifold checks if the polyhedron is a valid 2-manifold.
 // A valid 2-manifold requires:
 // - Each edge connects exactly 2 faces (except boundary edges which have 1)
@@ -29,41 +271,63 @@ func (p *Polyhedron) ValidateManifold() error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	// Check edge manifold property.
-	for _, edge := range p.Edges {
+	return firstError(p.manifoldIssues())
+}
+
+// manifoldIssues reports edges without two faces, with boundary edges as warnings,
+// and vertices whose faces do not form a cycle.
+func (p *Polyhedron) manifoldIssues() []ValidationIssue {
+	var issues []ValidationIssue
+
+	for _, edge := range sortedEdges(p) {
 		faceCount := len(edge.Faces)
+		if faceCount == 2 {
+			continue
+		}
 
-		if faceCount != 2 {
-			if faceCount == 1 {
-				// Boundary edge - this might be valid for open meshes.
-				// For closed polyhedra, all edges should have exactly 2 faces.
-				continue
-			}
+		issue := ValidationIssue{
+			Check:     checkManifold,
+			Severity:  SeverityError,
+			Element:   EdgeElement.String(),
+			IDs:       []int{edge.ID},
+			Message:   fmt.Sprintf("Edge %d has %d faces (expected 2)", edge.ID, faceCount),
+			Value:     float64(faceCount),
+			Tolerance: 2,
+		}
 
-			return ValidationError{
-				Type:    "Manifold",
-				Message: fmt.Sprintf("Edge %d has %d faces (expected 2)", edge.ID, faceCount),
-			}
+		if faceCount == 1 {
+			// Boundary edge - this might be valid for open meshes.
+			// For closed polyhedra, all edges should have exactly 2 faces.
+			issue.Severity = SeverityWarning
+			issue.Message = fmt.Sprintf("Edge %d is on an open boundary", edge.ID)
 		}
+
+		issues = append(issues, issue)
 	}
 
-	// Check vertex manifold property.
-	for _, vertex := range p.Vertices {
-		if err := p.validateVertexManifold(vertex); err != nil {
-			return err
+	for _, vertex := range sortedVertices(p) {
+		if issue := vertexManifoldIssue(vertex); issue != nil {
+			issues = append(issues, *issue)
 		}
 	}
 
-	return nil
+	return issues
 }
 
-// validateVertexManifold checks if faces around a vertex form a proper manifold.
-func (p *Polyhedron) validateVertexManifold(vertex *Vertex) error {
-	if len(vertex.Faces) < 3 {
-		return ValidationError{
-			Type:    "Manifold",
-			Message: fmt.Sprintf("Vertex %d has only %d faces (minimum 3)", vertex.ID, len(vertex.Faces)),
-		}
+// vertexManifoldIssue checks if faces around a vertex form a proper manifold.
+func vertexManifoldIssue(vertex *Vertex) *ValidationIssue {
+	issue := &ValidationIssue{
+		Check:     checkManifold,
+		Severity:  SeverityError,
+		Element:   VertexElement.String(),
+		IDs:       []int{vertex.ID},
+		Message:   fmt.Sprintf("Vertex %d has only %d faces (minimum 3)", vertex.ID, len(vertex.Faces)),
+		Value:     float64(len(vertex.Faces)),
+		Tolerance: minDegree,
+	}
+
+	if len(vertex.Faces) < minDegree {
+		return issue
 	}
 
 	// Check that faces around vertex form a connected cycle.
@@ -71,10 +335,11 @@ func (p *Polyhedron) validateVertexManifold(vertex *Vertex) error {
 	orderedFaces := OrderFacesAroundVertex(vertex)
 
 	if len(orderedFaces) != len(vertex.Faces) {
-		return ValidationError{
-			Type:    "Manifold",
-			Message: fmt.Sprintf("Vertex %d faces don't form a connected cycle", vertex.ID),
-		}
+		issue.Message = fmt.Sprintf("Vertex %d faces don't form a connected cycle", vertex.ID)
+		issue.Value = float64(len(orderedFaces))
+		issue.Tolerance = float64(len(vertex.Faces))
+
+		return issue
 	}
 
 	return nil
@@ -86,155 +351,532 @@ func (p *Polyhedron) ValidatePlanarity() error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	const tolerance = 1e-10
+	return firstError(p.planarityIssues(DefaultValidationTolerances().Planarity))
+}
+
+// planarityIssues reports, for each non-planar face, its vertex furthest from the
+// plane through its first three vertices.
+func (p *Polyhedron) planarityIssues(tolerance float64) []ValidationIssue {
+	var issues []ValidationIssue
+
+	for _, face := range sortedFaces(p) {
+		if len(face.Vertices) < minFaceVertices {
+			continue // Triangular faces are always planar
+		}
+
+		// Calculate the plane from the first three vertices.
+		v0 := face.Vertices[0].Position
+		normal := face.Vertices[1].Position.Sub(v0).Cross(face.Vertices[2].Position.Sub(v0)).Normalize()
+
+		worst, worstDist := 0, 0.0
+
+		for i := 3; i < len(face.Vertices); i++ {
+			// Calculate distance from point to plane.
+			if dist := math.Abs(normal.Dot(face.Vertices[i].Position.Sub(v0))); dist > worstDist {
+				worst, worstDist = i, dist
+			}
+		}
+
+		if worstDist > tolerance {
+			issues = append(issues, ValidationIssue{
+				Check:    checkPlanarity,
+				Severity: SeverityError,
+				Element:  FaceElement.String(),
+				IDs:      []int{face.ID},
+				Message: fmt.Sprintf("Face %d vertex %d is %.2e units from face plane (tolerance: %.2e)",
+					face.ID, worst, worstDist, tolerance),
+				Value:     worstDist,
+				Tolerance: tolerance,
+			})
+		}
+	}
+
+	return issues
+}
+
+// ValidateWinding checks that faces are wound consistently, counter-clockwise when
+// viewed from outside. Every edge shared by two faces must be traversed in opposite
+// directions by them. Each component of a closed surface must enclose a positive
+// signed volume, unless it is nested inside an odd number of other components, as the
+// inner walls of hollow solids are, when its volume must be negative. Open surfaces,
+// whose volume is undefined, instead have each face normal checked against the
+// direction from the centroid.
+// Thread-safe for concurrent access.
+func (p *Polyhedron) ValidateWinding() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return firstError(p.windingIssues())
+}
+
+// windingIssues reports edges traversed the same way by both their faces. If there
+// are none, it reports the components of a closed surface wound against their
+// nesting, or the faces of an open surface that point towards the centroid.
+func (p *Polyhedron) windingIssues() []ValidationIssue {
+	var issues []ValidationIssue
+
+	closed := true
+
+	for _, edge := range sortedEdges(p) {
+		if len(edge.Faces) != 2 {
+			closed = false
 
-	for _, face := range p.Faces {
-		if len(face.Vertices) <= 3 {
-			// Triangular faces are always planar.
 			continue
 		}
 
-		if err := p.validateFacePlanarity(face, tolerance); err != nil {
-			return err
+		if issue := edgeWindingIssue(edge); issue != nil {
+			issues = append(issues, *issue)
 		}
 	}
 
-	return nil
+	if len(issues) > 0 {
+		return issues
+	}
+
+	centroid := p.calculateCentroidUnsafe()
+
+	if closed {
+		return nestedWindingIssues(faceComponents(p), centroid)
+	}
+
+	for _, face := range sortedFaces(p) {
+		if issue := faceWindingIssue(face, centroid); issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+
+	return issues
 }
 
-// validateFacePlanarity checks if a specific face is planar within tolerance.
-func (p *Polyhedron) validateFacePlanarity(face *Face, tolerance float64) error {
-	if len(face.Vertices) < minFaceVertices {
-		return nil // Triangular faces are always planar
+// edgeWindingIssue checks that the two faces of an edge traverse it in opposite
+// directions.
+func edgeWindingIssue(edge *Edge) *ValidationIssue {
+	var starts []int
+
+	var faceIDs []int
+
+	for _, face := range sortedFacesOf(edge) {
+		i := FindEdgeIndex(face, edge)
+		if i < 0 {
+			continue
+		}
+
+		starts = append(starts, face.Vertices[i].ID)
+		faceIDs = append(faceIDs, face.ID)
 	}
 
-	// Calculate the plane from the first three vertices.
-	v0 := face.Vertices[0].Position
+	if len(starts) != 2 || starts[0] != starts[1] {
+		return nil
+	}
 
-	v1 := face.Vertices[1].Position
+	return &ValidationIssue{
+		Check:     checkWinding,
+		Severity:  SeverityError,
+		Element:   EdgeElement.String(),
+		IDs:       []int{edge.ID},
+		Message:   fmt.Sprintf("Faces %d and %d traverse edge %d in the same direction", faceIDs[0], faceIDs[1], edge.ID),
+		Value:     0,
+		Tolerance: 0,
+	}
+}
 
-	v2 := face.Vertices[2].Position
+// nestedWindingIssues reports each component of a closed surface whose signed volume
+// has the wrong sign for its nesting depth, the number of other components around it:
+// outer surfaces must enclose positive volume and the cavities inside them negative.
+// A single wrongly wound surface is reported as a whole.
+func nestedWindingIssues(components [][]*Face, centroid Vector3) []ValidationIssue {
+	var issues []ValidationIssue
 
-	// Calculate normal vector.
-	edge1 := v1.Sub(v0)
+	polygons := make([][][]Vector3, len(components))
 
-	edge2 := v2.Sub(v0)
+	for i, component := range components {
+		polygons[i] = make([][]Vector3, len(component))
 
-	normal := edge1.Cross(edge2).Normalize()
+		for j, face := range component {
+			polygons[i][j] = make([]Vector3, len(face.Vertices))
 
-	// Check if all other vertices lie in the same plane.
-	for i := 3; i < len(face.Vertices); i++ {
-		vi := face.Vertices[i].Position
-		// Calculate distance from point to plane.
-		dist := math.Abs(normal.Dot(vi.Sub(v0)))
+			for k, v := range face.Vertices {
+				polygons[i][j][k] = v.Position
+			}
+		}
+	}
 
-		if dist > tolerance {
-			return ValidationError{
-				Type: "Planarity",
-				Message: fmt.Sprintf("Face %d vertex %d is %.2e units from face plane (tolerance: %.2e)",
-					face.ID, i, dist, tolerance),
+	depths := nestingDepths(polygons)
+
+	for i, component := range components {
+		volume := signedVolume(component, centroid)
+		depth := depths[i]
+
+		cavity := depth%2 == 1
+		if (volume > 0) != cavity {
+			continue
+		}
+
+		message := fmt.Sprintf("Surface encloses a signed volume of %.2e (faces wound inwards)", volume)
+		if len(components) > 1 {
+			expected := "outwards"
+			if cavity {
+				expected = "into the cavity"
+			}
+
+			message = fmt.Sprintf("Component containing face %d at nesting depth %d encloses a signed "+
+				"volume of %.2e (faces should be wound %s)", component[0].ID, depth, volume, expected)
+		}
+
+		issues = append(issues, ValidationIssue{
+			Check:     checkWinding,
+			Severity:  SeverityError,
+			Element:   PolyhedronElement,
+			IDs:       nil,
+			Message:   message,
+			Value:     volume,
+			Tolerance: 0,
+		})
+	}
+
+	return issues
+}
+
+// faceComponents groups the faces into components connected through shared edges,
+// each in ID order, ordered by their lowest face ID.
+func faceComponents(p *Polyhedron) [][]*Face {
+	visited := make(map[int]bool, len(p.Faces))
+
+	var components [][]*Face
+
+	for _, start := range sortedFaces(p) {
+		if visited[start.ID] {
+			continue
+		}
+
+		visited[start.ID] = true
+		component := []*Face{start}
+
+		for next := 0; next < len(component); next++ {
+			for _, e := range component[next].Edges {
+				for _, f := range e.Faces {
+					if !visited[f.ID] {
+						visited[f.ID] = true
+						component = append(component, f)
+					}
+				}
+			}
+		}
+
+		slices.SortFunc(component, func(a, b *Face) int { return cmp.Compare(a.ID, b.ID) })
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// nestingDepths returns, for each closed component given as the corners of its
+// faces, the number of other components enclosing it. Components are assumed not
+// to cross, so each is tested at its first corner.
+func nestingDepths(components [][][]Vector3) []int {
+	// insideWinding separates winding numbers of ±1, inside, from 0, outside.
+	const insideWinding = 0.5
+
+	depths := make([]int, len(components))
+
+	for i, component := range components {
+		for j, other := range components {
+			if j != i && math.Abs(windingNumber(other, component[0][0])) > insideWinding {
+				depths[i]++
 			}
 		}
 	}
 
+	return depths
+}
+
+// windingNumber returns how many times the polygons wind around a point not on them:
+// the sum of their signed solid angles over 4π, ±1 inside a closed surface and 0
+// outside. Polygons are fan-triangulated and each triangle's solid angle is found
+// with the formula of Van Oosterom and Strackee.
+func windingNumber(polygons [][]Vector3, point Vector3) float64 {
+	const fullSolidAngle = 4 * math.Pi
+
+	total := 0.0
+
+	for _, corners := range polygons {
+		a := corners[0].Sub(point)
+
+		for i := 1; i+1 < len(corners); i++ {
+			b := corners[i].Sub(point)
+			c := corners[i+1].Sub(point)
+			la, lb, lc := a.Length(), b.Length(), c.Length()
+
+			numerator := a.Dot(b.Cross(c))
+			denominator := la*lb*lc + a.Dot(b)*lc + a.Dot(c)*lb + b.Dot(c)*la
+			total += 2 * math.Atan2(numerator, denominator)
+		}
+	}
+
+	return total / fullSolidAngle
+}
+
+// signedVolume returns the volume enclosed by the faces, positive when they are wound
+// outwards. Faces are fan-triangulated into tetrahedra with apex at origin.
+func signedVolume(faces []*Face, origin Vector3) float64 {
+	const tetrahedronScale = 6
+
+	volume := 0.0
+
+	for _, face := range faces {
+		a := face.Vertices[0].Position.Sub(origin)
+
+		for i := 1; i+1 < len(face.Vertices); i++ {
+			b := face.Vertices[i].Position.Sub(origin)
+			c := face.Vertices[i+1].Position.Sub(origin)
+			volume += a.Dot(b.Cross(c)) / tetrahedronScale
+		}
+	}
+
+	return volume
+}
+
+// faceWindingIssue checks if a face has correct winding order.
+func faceWindingIssue(face *Face, polyhedronCentroid Vector3) *ValidationIssue {
+	issue := &ValidationIssue{
+		Check:     checkWinding,
+		Severity:  SeverityError,
+		Element:   FaceElement.String(),
+		IDs:       []int{face.ID},
+		Message:   fmt.Sprintf("Face %d has insufficient vertices for winding check", face.ID),
+		Value:     float64(len(face.Vertices)),
+		Tolerance: minDegree,
+	}
+
+	if len(face.Vertices) < minDegree {
+		return issue
+	}
+
+	// Vector from polyhedron center to face center.
+	outwardVector := face.Centroid().Sub(polyhedronCentroid).Normalize()
+
+	// If face normal points outward, winding should be counter-clockwise when viewed from outside.
+	dotProduct := face.Normal().Dot(outwardVector)
+
+	if dotProduct < -windingDotTolerance {
+		issue.Message = fmt.Sprintf("Face %d has incorrect winding order (normal points inward)", face.ID)
+		issue.Value = dotProduct
+		issue.Tolerance = -windingDotTolerance
+
+		return issue
+	}
+
 	return nil
 }
 
-// ValidateWinding checks if all faces have consistent winding order (CCW from outside)
+// ValidateConvexity checks that the polyhedron is convex: no edge may be reflex, with
+// a dihedral angle greater than π, and every vertex must lie on or behind the plane
+// of every other face. The error lists each reflex edge with its dihedral angle,
+// followed by each vertex in front of a face plane with its distance. Faces should be wound
+// outwards, as checked by ValidateWinding. ValidateComplete does not check convexity,
+// since non-convex polyhedra are valid.
 // Thread-safe for concurrent access.
-func (p *Polyhedron) ValidateWinding() error {
+func (p *Polyhedron) ValidateConvexity() error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	centroid := p.calculateCentroidUnsafe()
+	issues := p.convexityIssues(DefaultValidationTolerances().Convexity)
+	if len(issues) == 0 {
+		return nil
+	}
 
-	for _, face := range p.Faces {
-		if err := p.validateFaceWinding(face, centroid); err != nil {
-			return err
+	reflex := 0
+	outside := make(map[int]bool)
+
+	for _, issue := range issues {
+		if issue.Element == EdgeElement.String() {
+			reflex++
+		} else {
+			outside[issue.IDs[0]] = true // A vertex may be in front of several faces
 		}
 	}
 
-	return nil
+	var parts []string
+
+	if reflex > 0 {
+		parts = append(parts, fmt.Sprintf("%d reflex edges", reflex))
+	}
+
+	if len(outside) > 0 {
+		parts = append(parts, fmt.Sprintf("%d vertices in front of face planes (tolerance: %.2e)",
+			len(outside), issues[len(issues)-1].Tolerance))
+	}
+
+	return joinIssues(checkConvexity, strings.Join(parts, ", "), issues)
 }
 
-// validateFaceWinding checks if a face has correct winding order.
-func (p *Polyhedron) validateFaceWinding(face *Face, polyhedronCentroid Vector3) error {
-	if len(face.Vertices) < 3 {
-		return ValidationError{
-			Type:    "Winding",
-			Message: fmt.Sprintf("Face %d has insufficient vertices for winding check", face.ID),
+// convexityIssues reports reflex edges, then vertices in front of the plane of a face
+// they do not belong to.
+func (p *Polyhedron) convexityIssues(tolerance float64) []ValidationIssue {
+	var issues []ValidationIssue
+
+	for _, edge := range sortedEdges(p) {
+		if angle, ok := edge.DihedralAngle(); ok && angle > math.Pi+tolerance {
+			issues = append(issues, ValidationIssue{
+				Check:     checkConvexity,
+				Severity:  SeverityError,
+				Element:   EdgeElement.String(),
+				IDs:       []int{edge.ID},
+				Message:   fmt.Sprintf("Edge %d is reflex (%.4f°)", edge.ID, angle*radiansToDegrees),
+				Value:     angle,
+				Tolerance: math.Pi + tolerance,
+			})
 		}
 	}
 
-	faceNormal := face.Normal()
+	centroid := p.calculateCentroidUnsafe()
+	radius := 0.0
+
+	for _, v := range p.Vertices {
+		radius = math.Max(radius, v.Position.Distance(centroid))
+	}
 
-	faceCentroid := face.Centroid()
+	distanceTolerance := tolerance * radius
+	vertices := sortedVertices(p)
 
-	// Vector from polyhedron center to face center.
-	outwardVector := faceCentroid.Sub(polyhedronCentroid).Normalize()
+	for _, face := range sortedFaces(p) {
+		normal, point := face.Normal(), face.Centroid()
 
-	// If face normal points outward, winding should be counter-clockwise when viewed from outside.
-	dotProduct := faceNormal.Dot(outwardVector)
+		for _, v := range vertices {
+			if slices.Contains(face.Vertices, v) {
+				continue // Planarity of the face's own vertices is checked by ValidatePlanarity
+			}
 
-	if dotProduct < -0.1 { // Allow some tolerance
-		return ValidationError{
-			Type:    "Winding",
-			Message: fmt.Sprintf("Face %d has incorrect winding order (normal points inward)", face.ID),
+			if dist := normal.Dot(v.Position.Sub(point)); dist > distanceTolerance {
+				issues = append(issues, ValidationIssue{
+					Check:     checkConvexity,
+					Severity:  SeverityError,
+					Element:   VertexElement.String(),
+					IDs:       []int{v.ID},
+					Message:   fmt.Sprintf("Vertex %d is %.2e in front of face %d", v.ID, dist, face.ID),
+					Value:     dist,
+					Tolerance: distanceTolerance,
+				})
+			}
 		}
 	}
 
-	return nil
+	return issues
 }
 
-// ValidateTopology performs comprehensive topology validation.
+// ValidateTopology performs comprehensive topology validation. The Euler
+// characteristic must be that of closed orientable surfaces: 2 for a single sphere,
+// with 2 more for each further component and 2 less for each handle.
 // Thread-safe for concurrent access.
 func (p *Polyhedron) ValidateTopology() error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	// Check Euler characteristic.
-	euler := len(p.Vertices) - len(p.Edges) + len(p.Faces) // Calculate inline to avoid deadlock
+	return firstError(p.topologyIssues())
+}
 
-	if euler != 2 {
-		return ValidationError{
-			Type:    "Topology",
-			Message: fmt.Sprintf("Invalid Euler characteristic: %d (expected 2)", euler),
-		}
+// topologyIssues reports an invalid Euler characteristic, vertices and faces of low
+// degree, and edges with no faces or more than two.
+func (p *Polyhedron) topologyIssues() []ValidationIssue {
+	var issues []ValidationIssue
+
+	// Check Euler characteristic. Each connected closed surface contributes 2 minus
+	// twice its genus: 2 for a sphere, less for the tunnels of a strut lattice.
+	euler := len(p.Vertices) - len(p.Edges) + len(p.Faces) // Calculate inline to avoid deadlock
+	components := p.countComponentsUnsafe()
+
+	if euler%2 != 0 || euler > 2*components {
+		issues = append(issues, ValidationIssue{
+			Check:    checkTopology,
+			Severity: SeverityError,
+			Element:  PolyhedronElement,
+			IDs:      nil,
+			Message: fmt.Sprintf("Invalid Euler characteristic: %d (expected an even number at most %d for %d components)",
+				euler, 2*components, components),
+			Value:     float64(euler),
+			Tolerance: float64(2 * components),
+		})
 	}
 
 	// Check minimum vertex degree.
-	for _, vertex := range p.Vertices {
-		if vertex.Degree() < 3 {
-			return ValidationError{
-				Type:    "Topology",
-				Message: fmt.Sprintf("Vertex %d has degree %d (minimum 3)", vertex.ID, vertex.Degree()),
-			}
+	for _, vertex := range sortedVertices(p) {
+		if vertex.Degree() < minDegree {
+			issues = append(issues, ValidationIssue{
+				Check:     checkTopology,
+				Severity:  SeverityError,
+				Element:   VertexElement.String(),
+				IDs:       []int{vertex.ID},
+				Message:   fmt.Sprintf("Vertex %d has degree %d (minimum 3)", vertex.ID, vertex.Degree()),
+				Value:     float64(vertex.Degree()),
+				Tolerance: minDegree,
+			})
 		}
 	}
 
 	// Check minimum face degree.
-	for _, face := range p.Faces {
-		if face.Degree() < 3 {
-			return ValidationError{
-				Type:    "Topology",
-				Message: fmt.Sprintf("Face %d has degree %d (minimum 3)", face.ID, face.Degree()),
-			}
+	for _, face := range sortedFaces(p) {
+		if face.Degree() < minDegree {
+			issues = append(issues, ValidationIssue{
+				Check:     checkTopology,
+				Severity:  SeverityError,
+				Element:   FaceElement.String(),
+				IDs:       []int{face.ID},
+				Message:   fmt.Sprintf("Face %d has degree %d (minimum 3)", face.ID, face.Degree()),
+				Value:     float64(face.Degree()),
+				Tolerance: minDegree,
+			})
 		}
 	}
 
 	// Check edge-face connectivity.
-	for _, edge := range p.Edges {
+	for _, edge := range sortedEdges(p) {
 		faceCount := len(edge.Faces)
 
 		if faceCount == 0 || faceCount > 2 {
-			return ValidationError{
-				Type:    "Topology",
-				Message: fmt.Sprintf("Edge %d has %d faces (expected 1 or 2)", edge.ID, faceCount),
+			issues = append(issues, ValidationIssue{
+				Check:     checkTopology,
+				Severity:  SeverityError,
+				Element:   EdgeElement.String(),
+				IDs:       []int{edge.ID},
+				Message:   fmt.Sprintf("Edge %d has %d faces (expected 1 or 2)", edge.ID, faceCount),
+				Value:     float64(faceCount),
+				Tolerance: 2,
+			})
+		}
+	}
+
+	return issues
+}
+
+// countComponentsUnsafe returns the number of connected components of the vertex-edge
+// graph. It performs no locking.
+func (p *Polyhedron) countComponentsUnsafe() int {
+	visited := make(map[int]bool, len(p.Vertices))
+	components := 0
+
+	for _, start := range p.Vertices {
+		if visited[start.ID] {
+			continue
+		}
+
+		components++
+		visited[start.ID] = true
+		stack := []*Vertex{start}
+
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			for _, e := range v.Edges {
+				if other := e.OtherVertex(v); other != nil && !visited[other.ID] {
+					visited[other.ID] = true
+					stack = append(stack, other)
+				}
 			}
 		}
 	}
 
-	return nil
+	return components
 }
 
 // ValidateGeometry performs geometric validation checks.
@@ -243,57 +885,48 @@ func (p *Polyhedron) ValidateGeometry() error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	// Check for degenerate edges (zero length)
-	const minEdgeLength = 1e-12
+	return firstError(p.geometryIssues(DefaultValidationTolerances()))
+}
 
-	for _, edge := range p.Edges {
-		length := edge.Length()
+// geometryIssues reports degenerate edges and faces.
+func (p *Polyhedron) geometryIssues(tolerances ValidationTolerances) []ValidationIssue {
+	var issues []ValidationIssue
 
-		if length < minEdgeLength {
-			return ValidationError{
-				Type:    "Geometry",
-				Message: fmt.Sprintf("Edge %d has degenerate length: %e", edge.ID, length),
-			}
+	// Check for degenerate edges (zero length)
+	for _, edge := range sortedEdges(p) {
+		if length := edge.Length(); length < tolerances.EdgeLength {
+			issues = append(issues, ValidationIssue{
+				Check:     checkGeometry,
+				Severity:  SeverityError,
+				Element:   EdgeElement.String(),
+				IDs:       []int{edge.ID},
+				Message:   fmt.Sprintf("Edge %d has degenerate length: %e", edge.ID, length),
+				Value:     length,
+				Tolerance: tolerances.EdgeLength,
+			})
 		}
 	}
 
 	// Check for degenerate faces (zero area)
-	const minFaceArea = 1e-12
-
-	for _, face := range p.Faces {
-		area := face.Area()
-		if area < minFaceArea {
-			return ValidationError{
-				Type:    "Geometry",
-				Message: fmt.Sprintf("Face %d has degenerate area: %e", face.ID, area),
-			}
+	for _, face := range sortedFaces(p) {
+		if area := face.Area(); area < tolerances.FaceArea {
+			issues = append(issues, ValidationIssue{
+				Check:     checkGeometry,
+				Severity:  SeverityError,
+				Element:   FaceElement.String(),
+				IDs:       []int{face.ID},
+				Message:   fmt.Sprintf("Face %d has degenerate area: %e", face.ID, area),
+				Value:     area,
+				Tolerance: tolerances.FaceArea,
+			})
 		}
 	}
 
-	return nil
+	return issues
 }
 
-// ValidateComplete performs all validation checks.
+// ValidateComplete performs all validation checks, returning the first error found.
+// Validate reports every issue instead.
 func (p *Polyhedron) ValidateComplete() error {
-	if err := p.ValidateTopology(); err != nil {
-		return err
-	}
-
-	if err := p.ValidateManifold(); err != nil {
-		return err
-	}
-
-	if err := p.ValidatePlanarity(); err != nil {
-		return err
-	}
-
-	if err := p.ValidateWinding(); err != nil {
-		return err
-	}
-
-	if err := p.ValidateGeometry(); err != nil {
-		return err
-	}
-
-	return nil
+	return p.Validate().Err()
 }
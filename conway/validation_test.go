@@ -1,6 +1,12 @@
 package conway_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"testing"
 
@@ -67,3 +73,332 @@ func TestValidateManifoldErrors(t *testing.T) {
 		t.Error("Expected manifold validation error for non-manifold polyhedron")
 	}
 }
+
+func TestValidateTopologyEuler(t *testing.T) {
+	t.Parallel()
+
+	open := conway.Cube()
+
+	for _, f := range open.Faces {
+		open.RemoveFace(f)
+
+		break
+	}
+
+	if err := open.ValidateTopology(); err == nil {
+		t.Error("Expected topology validation error for odd Euler characteristic")
+	}
+
+	// A lattice with a tunnel through every face is a valid higher-genus surface.
+	lattice, err := conway.Struts(conway.Cube(), 0.2, 0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lattice.ValidateTopology(); err != nil {
+		t.Errorf("Expected lattice to pass topology validation: %v", err)
+	}
+}
+
+func TestValidateWindingHollow(t *testing.T) {
+	t.Parallel()
+
+	// The inner walls of a shell face its centroid yet are wound consistently.
+	shell, err := conway.Shell(conway.Octahedron(), 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := shell.ValidateWinding(); err != nil {
+		t.Errorf("Expected shell to pass winding validation: %v", err)
+	}
+}
+
+func TestValidateWindingInsideOutCavity(t *testing.T) {
+	t.Parallel()
+
+	shell, err := conway.Shell(conway.Octahedron(), 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(shell)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Version  int `json:"version"`
+		Vertices []struct {
+			ID       int        `json:"id"`
+			Position [3]float64 `json:"position"`
+		} `json:"vertices"`
+		Faces []struct {
+			ID       int   `json:"id"`
+			Vertices []int `json:"vertices"`
+		} `json:"faces"`
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	// Turn the cavity's faces, whose vertices are nearer the centre, to point
+	// outwards, so its volume adds to the shell's instead of being taken away.
+	radius := func(index int) float64 {
+		p := doc.Vertices[index].Position
+		return math.Sqrt(p[0]*p[0] + p[1]*p[1] + p[2]*p[2])
+	}
+
+	for _, f := range doc.Faces {
+		if radius(f.Vertices[0]) < radius(doc.Faces[0].Vertices[0])-0.05 {
+			slices.Reverse(f.Vertices)
+		}
+	}
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var inverted conway.Polyhedron
+	if err := json.Unmarshal(data, &inverted); err != nil {
+		t.Fatal(err)
+	}
+
+	if inverted.Volume() <= shell.Volume() {
+		t.Fatalf("Expected the inverted cavity to add volume: %.4f <= %.4f", inverted.Volume(), shell.Volume())
+	}
+
+	err = inverted.ValidateWinding()
+	if err == nil || !strings.Contains(err.Error(), "nesting depth 1") {
+		t.Errorf("Expected the cavity to be reported as wound outwards, got: %v", err)
+	}
+
+	if inverted.ValidateComplete() == nil {
+		t.Error("Expected an inside-out cavity to fail complete validation")
+	}
+}
+
+func TestValidateConvexity(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "C", "D", "tI", "aC", "kC"} {
+		if err := conway.MustParse(notation).ValidateConvexity(); err != nil {
+			t.Errorf("Expected %s to be convex: %v", notation, err)
+		}
+	}
+
+	// Pyramids raised on the faces of kC fold inwards where they meet.
+	err := conway.MustParse("kkC").ValidateConvexity()
+	if err == nil {
+		t.Fatal("Expected kkC to have reflex edges")
+	}
+
+	if !strings.Contains(err.Error(), "reflex edges") || !strings.Contains(err.Error(), "°") {
+		t.Errorf("Expected reflex edges with their dihedral angles, got: %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "in front of face") {
+		t.Errorf("Expected vertices in front of face planes alongside the reflex edges, got: %v", err)
+	}
+
+	// Vertices in front of several faces are counted once.
+	pairs := 0
+	vertices := make(map[int]bool)
+
+	for _, issue := range conway.MustParse("kkC").Validate(conway.WithConvexityCheck()).Issues {
+		if issue.Check == "Convexity" && issue.Element == "vertex" {
+			pairs++
+			vertices[issue.IDs[0]] = true
+		}
+	}
+
+	if pairs <= len(vertices) {
+		t.Fatalf("Expected some vertices of kkC in front of several faces, got %d pairs for %d vertices", pairs, len(vertices))
+	}
+
+	if want := fmt.Sprintf("%d vertices in front of face planes", len(vertices)); !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected %q, got: %v", want, err)
+	}
+}
+
+func TestValidateConvexPosition(t *testing.T) {
+	t.Parallel()
+
+	// A strip of squares bending convexly at every edge but curling back under its
+	// first face, so the far end lies in front of that face's plane.
+	p := conway.NewPolyhedron("curl")
+	profile := [][2]float64{{0, 0}, {1, 0}, {1, 1}, {-1, 1}, {-1, -1}}
+
+	var near, far []*conway.Vertex
+
+	for _, xz := range profile {
+		near = append(near, p.AddVertex(conway.Vector3{X: xz[0], Y: 0, Z: xz[1]}))
+		far = append(far, p.AddVertex(conway.Vector3{X: xz[0], Y: 1, Z: xz[1]}))
+	}
+
+	for i := range len(profile) - 1 {
+		p.AddFace([]*conway.Vertex{near[i], far[i], far[i+1], near[i+1]})
+	}
+
+	err := p.ValidateConvexity()
+	if err == nil {
+		t.Fatal("Expected the curled strip to fail convex position")
+	}
+
+	if strings.Contains(err.Error(), "reflex") || !strings.Contains(err.Error(), "in front of face") {
+		t.Errorf("Expected only vertices in front of face planes, got: %v", err)
+	}
+}
+
+func TestValidateSelfIntersection(t *testing.T) {
+	t.Parallel()
+
+	for _, notation := range []string{"T", "C", "tI", "kkC", "ttttI"} {
+		if err := conway.MustParse(notation).ValidateSelfIntersection(); err != nil {
+			t.Errorf("Expected %s not to intersect itself: %v", notation, err)
+		}
+	}
+
+	// A square in the XY plane is pierced by a square standing across it and
+	// overlapped by a square in the same plane, while a fourth square lies apart.
+	p := conway.NewPolyhedron("crossing")
+	square := func(corners ...conway.Vector3) *conway.Face {
+		vertices := make([]*conway.Vertex, len(corners))
+
+		for i, c := range corners {
+			vertices[i] = p.AddVertex(c)
+		}
+
+		return p.AddFace(vertices)
+	}
+
+	flat := square(conway.Vector3{X: -1, Y: -1, Z: 0}, conway.Vector3{X: 1, Y: -1, Z: 0},
+		conway.Vector3{X: 1, Y: 1, Z: 0}, conway.Vector3{X: -1, Y: 1, Z: 0})
+	standing := square(conway.Vector3{X: 0, Y: -0.5, Z: -1}, conway.Vector3{X: 0, Y: 0.5, Z: -1},
+		conway.Vector3{X: 0, Y: 0.5, Z: 1}, conway.Vector3{X: 0, Y: -0.5, Z: 1})
+	overlapping := square(conway.Vector3{X: 0.5, Y: 0.5, Z: 0}, conway.Vector3{X: 2.5, Y: 0.5, Z: 0},
+		conway.Vector3{X: 2.5, Y: 2.5, Z: 0}, conway.Vector3{X: 0.5, Y: 2.5, Z: 0})
+	square(conway.Vector3{X: 5, Y: 5, Z: 5}, conway.Vector3{X: 6, Y: 5, Z: 5},
+		conway.Vector3{X: 6, Y: 6, Z: 5}, conway.Vector3{X: 5, Y: 6, Z: 5})
+
+	pairs := p.SelfIntersections()
+	expected := [][2]int{{flat.ID, standing.ID}, {flat.ID, overlapping.ID}}
+
+	if len(pairs) != len(expected) || pairs[0] != expected[0] || pairs[1] != expected[1] {
+		t.Errorf("Expected intersecting pairs %v, got %v", expected, pairs)
+	}
+
+	if err := p.ValidateSelfIntersection(); err == nil || !strings.Contains(err.Error(), "2 intersecting face pairs") {
+		t.Errorf("Expected both intersecting pairs to be reported, got: %v", err)
+	}
+}
+
+func TestValidateReport(t *testing.T) {
+	t.Parallel()
+
+	// Gyro's faces are not planar until canonicalized, and every one of them is
+	// reported, not only the first.
+	p := conway.MustParse("gC")
+	report := p.Validate()
+
+	if report.Valid() || report.Count(conway.SeverityError) != 12 {
+		t.Errorf("Expected 12 non-planar faces, got %d errors", report.Count(conway.SeverityError))
+	}
+
+	for _, issue := range report.Issues {
+		if issue.Check != "Planarity" || issue.Element != conway.FaceElement.String() || len(issue.IDs) != 1 ||
+			issue.Value <= issue.Tolerance || issue.Tolerance != 1e-10 {
+			t.Errorf("Unexpected issue: %+v", issue)
+		}
+	}
+
+	if err := p.ValidateComplete(); err == nil || err.Error() != report.Err().Error() {
+		t.Errorf("Expected ValidateComplete to return the report's first error, got: %v", err)
+	}
+
+	// A loose enough tolerance accepts the faces.
+	if loose := p.Validate(conway.WithPlanarityTolerance(1)); !loose.Valid() || loose.Tolerances.Planarity != 1 {
+		t.Errorf("Expected a loose planarity tolerance to pass, got: %v", loose.Err())
+	}
+
+	if tiny := conway.Cube().Validate(conway.WithDegenerateTolerance(10)); tiny.Count(conway.SeverityError) != 12+6 {
+		t.Errorf("Expected every edge and face to be degenerate at tolerance 10, got %d errors", tiny.Count(conway.SeverityError))
+	}
+}
+
+func TestValidateReportOptionalChecks(t *testing.T) {
+	t.Parallel()
+
+	// Reflex edges are warnings, since kkC is a valid polyhedron.
+	report := conway.MustParse("kkC").Validate(conway.WithConvexityCheck(), conway.WithSelfIntersectionCheck())
+
+	if !report.Valid() || report.Count(conway.SeverityWarning) == 0 {
+		t.Errorf("Expected only convexity warnings, got %d errors and %d warnings",
+			report.Count(conway.SeverityError), report.Count(conway.SeverityWarning))
+	}
+
+	if got := strings.Join(report.Checks, ","); got != "Topology,Manifold,Planarity,Winding,Orientability,Geometry,Convexity,SelfIntersection" {
+		t.Errorf("Unexpected checks: %s", got)
+	}
+
+	// A lone triangle has open boundary edges, reported as warnings.
+	tri := conway.NewPolyhedron("triangle")
+	tri.AddFace([]*conway.Vertex{
+		tri.AddVertex(conway.Vector3{X: 0, Y: 0, Z: 0}),
+		tri.AddVertex(conway.Vector3{X: 1, Y: 0, Z: 0}),
+		tri.AddVertex(conway.Vector3{X: 0, Y: 1, Z: 0}),
+	})
+
+	boundary := 0
+
+	for _, issue := range tri.Validate().Issues {
+		if issue.Severity == conway.SeverityWarning && strings.Contains(issue.Message, "open boundary") {
+			boundary++
+		}
+	}
+
+	if boundary != 3 {
+		t.Errorf("Expected 3 boundary warnings, got %d", boundary)
+	}
+}
+
+func TestValidationReportJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	if err := conway.MustParse("gC").Validate().WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Name       string `json:"name"`
+		Tolerances struct {
+			Planarity float64 `json:"planarity"`
+		} `json:"tolerances"`
+		Issues []struct {
+			Check    string `json:"check"`
+			Severity string `json:"severity"`
+			Element  string `json:"element"`
+			IDs      []int  `json:"ids"`
+		} `json:"issues"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Tolerances.Planarity != 1e-10 || len(doc.Issues) != 12 {
+		t.Errorf("Unexpected report: %s", buf.String())
+	}
+
+	if issue := doc.Issues[0]; issue.Severity != "error" || issue.Element != "face" || len(issue.IDs) != 1 {
+		t.Errorf("Unexpected issue: %+v", issue)
+	}
+
+	if err := conway.Cube().Validate().WriteJSON(failingWriter{}); !errors.Is(err, errWriteFailed) {
+		t.Errorf("Expected the write error, got: %v", err)
+	}
+}
@@ -50,6 +50,135 @@
 //	dual := conway.NewDual().Apply(cube)
 //	truncated := conway.NewTruncate().Apply(dual)
 //
+// # Caching
+//
+// An Evaluator caches intermediate results so that notations sharing a suffix
+// are only computed once:
+//
+//	evaluator := conway.NewEvaluator(256)
+//	truncated, _ := evaluator.Evaluate("tkI")
+//	dual, _ := evaluator.Evaluate("dtkI") // Reuses the cached "tkI"
+//
+// Results can also be kept between runs in a size-bounded DiskCache:
+//
+//	cache, err := conway.NewDiskCache("/var/cache/conway", 512<<20)
+//	p, err := conway.Parse("dtkI", conway.WithDiskCache(cache))
+//
+// An Evaluator given the same option shares the disk cache with Parse.
+//
+// # Provenance
+//
+// Every generated polyhedron records where each of its elements came from.
+// Provenance maps an output vertex, edge or face to the element of the input it
+// was derived from, and Resolve follows the chain back to the seed:
+//
+//	p, _ := conway.Parse("tkC")
+//	role, _ := p.Provenance.Role(conway.FaceElement, faceID) // e.g. "face from vertex"
+//	src, _ := p.Provenance.Resolve().Source(conway.FaceElement, faceID)
+//
+// Provenance is kept by Clone and by the JSON and binary encodings.
+//
+// # Attributes
+//
+// Vertices, edges and faces can carry typed attributes such as colours, tags and
+// texture coordinates. Each key declares how its values propagate through
+// operations: inherited from the source element, blended from its neighbours, or reset.
+//
+//	color := conway.ColorAttribute()
+//	color.Set(face, conway.RGB(1, 0, 0))
+//	kised := conway.Kis(p) // The triangles raised on face are red too
+//
+// Faces can be coloured by degree, by symmetry orbit, by the kind of seed element
+// they came from, or with the fewest colours such that neighbours differ:
+//
+//	conway.ProperColoring(p).Apply(p, conway.DefaultPalette())
+//
+// # Measurement
+//
+// Volume, SurfaceArea and MassProperties treat a polyhedron as a solid of unit
+// density. MassProperties gives the true centre of mass, which Centroid's vertex
+// average is not, along with the inertia tensor and principal axes.
+// AlignPrincipalAxes, or the WithPrincipalAxes parser option, moves the centre of
+// mass to the origin and turns the principal axes onto X, Y and Z:
+//
+//	props := p.MassProperties()
+//	fmt.Println(props.Volume, props.Centroid, props.PrincipalMoments)
+//
+// Radii reports the circumradius, midradius and inradius and whether each sphere
+// exists. Operations scale their results so the furthest vertex is at unit distance;
+// NormalizeTo, or the WithNormalization parser option, instead fixes the edge length,
+// midradius, inradius or volume at one:
+//
+//	p, err := conway.Parse("tI", conway.WithNormalization(conway.UnitEdgeLength))
+//
+// CalculateAngleStats measures each edge's dihedral angle, each face corner and
+// each vertex's angular defect, checks Descartes' theorem that the defects sum to
+// 2π times the Euler characteristic, and groups the angles into histograms by edge,
+// face and vertex type. Dihedral angles above π mark reflex edges.
+//
+// CalculateVertexConfigurations reads the faces around each vertex in order, giving
+// vertex configurations such as "5.6.6" for tI, with counts of each configuration
+// and of each face degree.
+//
+// # Rendering
+//
+// Polyhedra can be drawn as flat-shaded SVG images, using face colours where set:
+//
+//	err := conway.WriteSVG(w, p, conway.WithSize(800, 800), conway.WithCamera(conway.DefaultCamera()))
+//
+// RenderImage and WritePNG rasterize the same scene in pure Go, with a z-buffer
+// and anti-aliased edges, for environments without a browser or GPU.
+//
+// SchlegelDiagram and TutteEmbedding lay the vertex-edge graph out in the plane
+// with a chosen outer face, for drawing as SVG or as TikZ for LaTeX:
+//
+//	layout, err := conway.SchlegelDiagram(p, faceID)
+//	err = layout.WriteTikZ(w)
+//
+// Unfold lays the faces out flat as a printable paper net with cut lines, fold
+// lines and numbered glue tabs:
+//
+//	net, err := conway.Unfold(p)
+//	err = net.WriteSVG(w, conway.WithSize(2000, 2000))
+//
+// # Export
+//
+// Face.Triangulate splits a face into index triangles by ear clipping, which covers
+// non-convex faces exactly, as a fan, or around its centroid, and Triangulate or
+// TriangulateOp turns a whole polyhedron into an all-triangle mesh without moving
+// its vertices. The exporters below triangulate by ear clipping:
+//
+//	for _, tri := range f.Triangulate(conway.EarClipping) {
+//		a, b, c := f.Vertices[tri[0]], f.Vertices[tri[1]], f.Vertices[tri[2]]
+//	}
+//
+// WriteGLTF and WriteGLB export triangulated meshes as glTF 2.0 for web viewers and
+// game engines, with flat or smooth normals and face colours as vertex colours or
+// materials:
+//
+//	err := conway.WriteGLB(w, p, conway.WithNormals(conway.SmoothNormals))
+//
+// Write3MF produces 3MF packages for 3D printing, scaled to real units and with
+// face colours carried through to the printer:
+//
+//	err := conway.Write3MF(w, p, conway.WithCircumradius(30), conway.WithUnit(conway.Millimeter))
+//
+// A polyhedron is an infinitely thin surface. Struts turns it into a printable
+// wireframe of prismatic struts joined at vertex hubs, and Shell hollows it into a
+// shell with walls of a given thickness. Both produce closed, outward-wound surfaces
+// that pass ValidateComplete, ready for WriteSTL or Write3MF:
+//
+//	lattice, err := conway.Struts(p, 0.1, 0.05)
+//	err = conway.WriteSTL(w, lattice)
+//
+// Fabricate prepares a polyhedron, typically a geodesic dome, for building as a
+// frame: struts are grouped by length and labelled A, B, C and so on, and each hub
+// lists the axial and dihedral angles of its struts. A cut plane gives a flat-based
+// dome, and the report is written as CSV or as text:
+//
+//	report, err := conway.Fabricate(p, conway.WithDomeRadius(3), conway.WithCutPlane(center, up))
+//	err = report.WriteReport(os.Stdout)
+//
 // # Validation
 //
 // All generated polyhedra can be validated:
@@ -60,7 +189,41 @@
 //
 // The library ensures all operations preserve the topological validity
 // of polyhedra, maintaining Euler's formula (V - E + F = 2) and other
-// geometric invariants.
+// geometric invariants. Validation also accepts the closed surfaces of higher
+// genus or with several components produced by Struts and Shell.
+//
+// ValidateConvexity, which ValidateComplete does not run, reports the reflex edges
+// of non-convex results such as kkC with their dihedral angles, and any vertex in
+// front of another face's plane.
+//
+// ValidateSelfIntersection reports every pair of faces that cross each other, which
+// would break 3D printing; SelfIntersections returns the pairs as face IDs.
+//
+// ValidateComplete stops at the first error. Validate instead collects every issue
+// into a ValidationReport, each with its severity, element IDs, measured value and
+// tolerance. Tolerances are configurable, the convexity and self-intersection checks
+// can be added, and the report can be written as JSON for tooling:
+//
+//	report := p.Validate(conway.WithPlanarityTolerance(1e-8), conway.WithSelfIntersectionCheck())
+//	if !report.Valid() {
+//		err := report.WriteJSON(os.Stdout)
+//	}
+//
+// When validation fails, Repair returns a fixed copy with a report of its changes:
+// it welds coincident vertices, drops degenerate faces and zero-length edges, makes
+// the winding consistent by propagating it across shared edges, and moves vertices
+// until faces are planar:
+//
+//	fixed, changes, err := conway.Repair(p, conway.WithWeldTolerance(1e-6))
+//
+// AddFace winds each new face against the faces it shares edges with, so a surface
+// built one face at a time stays consistent even where faces are not convex. Orient
+// rewinds an existing surface in place the same way and turns it outwards, and
+// IsOrientable reports whether that is possible at all; a Möbius strip is not:
+//
+//	if _, err := p.Orient(); errors.Is(err, conway.ErrNonOrientable) {
+//		// Some edges are traversed twice in the same direction
+//	}
 //
 // # Thread Safety
 //
@@ -75,4 +238,10 @@
 //   - Lazy evaluation of computed properties
 //   - Memory-efficient half-edge data structure
 //   - Caching of expensive calculations
+//   - Parallel construction of operation output across CPU cores
+//
+// The number of goroutines each operation uses can be set per operation
+// (for example KisOp{Workers: 4}) or for a whole notation chain:
+//
+//	p, err := conway.Parse("tkatI", conway.WithWorkers(4))
 package conway